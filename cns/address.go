@@ -0,0 +1,146 @@
+package cns
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrWrongPrefix is returned when an address prefix doesn't match the expected AddressRole prefix.
+var ErrWrongPrefix = errors.New("wrong bech32 prefix")
+
+// AddressRole identifies which kind of entity a bech32 address refers to, and thus its prefix.
+type AddressRole int
+
+const (
+	// RoleAccount is an account address, e.g. cosmos1...
+	RoleAccount AddressRole = iota
+	// RoleAccountPub is an account public key, e.g. cosmospub1...
+	RoleAccountPub
+	// RoleValidator is a validator operator address, e.g. cosmosvaloper1...
+	RoleValidator
+	// RoleValidatorPub is a validator operator public key, e.g. cosmosvaloperpub1...
+	RoleValidatorPub
+	// RoleConsensus is a consensus node address, e.g. cosmosvalcons1...
+	RoleConsensus
+	// RoleConsensusPub is a consensus node public key, e.g. cosmosvalconspub1...
+	RoleConsensusPub
+)
+
+// String implements the fmt.Stringer interface.
+func (r AddressRole) String() string {
+	switch r {
+	case RoleAccount:
+		return "account"
+	case RoleAccountPub:
+		return "account public key"
+	case RoleValidator:
+		return "validator operator"
+	case RoleValidatorPub:
+		return "validator operator public key"
+	case RoleConsensus:
+		return "consensus node"
+	case RoleConsensusPub:
+		return "consensus node public key"
+	default:
+		return fmt.Sprintf("AddressRole(%d)", int(r))
+	}
+}
+
+// isPubKey returns true if r identifies a public key rather than an address.
+func (r AddressRole) isPubKey() bool {
+	return r == RoleAccountPub || r == RoleValidatorPub || r == RoleConsensusPub
+}
+
+// Prefix returns the bech32 prefix used for addresses of the given role.
+func (b Bech32Config) Prefix(role AddressRole) (string, error) {
+	var prefix string
+	switch role {
+	case RoleAccount:
+		prefix = b.Bech32PrefixAccAddr()
+	case RoleAccountPub:
+		prefix = b.Bech32PrefixAccPub()
+	case RoleValidator:
+		prefix = b.Bech32PrefixValAddr()
+	case RoleValidatorPub:
+		prefix = b.Bech32PrefixValPub()
+	case RoleConsensus:
+		prefix = b.Bech32PrefixConsAddr()
+	case RoleConsensusPub:
+		prefix = b.Bech32PrefixConsPub()
+	default:
+		return "", fmt.Errorf("unknown address role %s", role)
+	}
+
+	if b.MainPrefix == "" {
+		return "", errors.New("bech32 main prefix not defined")
+	}
+
+	return prefix, nil
+}
+
+// DecodeAddress decodes address into its raw bytes, checking that it carries the prefix for role.
+func (b Bech32Config) DecodeAddress(address string, role AddressRole) ([]byte, error) {
+	prefix, err := b.Prefix(role)
+	if err != nil {
+		return nil, err
+	}
+
+	hrp, bz, err := bech32DecodeBytes(address)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode %s address %s, %w", role, address, err)
+	}
+
+	if hrp != prefix {
+		return nil, fmt.Errorf("%w: %s address %s has prefix %s, expected %s", ErrWrongPrefix, role, address, hrp, prefix)
+	}
+
+	if err := verifyAddressBytes(bz, role); err != nil {
+		return nil, fmt.Errorf("invalid %s address %s, %w", role, address, err)
+	}
+
+	return bz, nil
+}
+
+// ValidateAddress returns an error if address is not a valid bech32 address for role.
+func (b Bech32Config) ValidateAddress(address string, role AddressRole) error {
+	_, err := b.DecodeAddress(address, role)
+	return err
+}
+
+// EncodeAddress encodes bz as a bech32 address with the prefix for role.
+func (b Bech32Config) EncodeAddress(bz []byte, role AddressRole) (string, error) {
+	prefix, err := b.Prefix(role)
+	if err != nil {
+		return "", err
+	}
+
+	if err := verifyAddressBytes(bz, role); err != nil {
+		return "", err
+	}
+
+	return bech32EncodeBytes(prefix, bz)
+}
+
+func verifyAddressBytes(bz []byte, role AddressRole) error {
+	if len(bz) == 0 {
+		return errors.New("empty address")
+	}
+
+	if !role.isPubKey() && len(bz) > bech32MaxAddrBytes {
+		return fmt.Errorf("address length %d exceeds %d bytes", len(bz), bech32MaxAddrBytes)
+	}
+
+	return nil
+}
+
+// ValidateDemerisAddresses returns an error if any of c's DemerisAddresses is not a valid
+// account address for c.
+func (c Chain) ValidateDemerisAddresses() error {
+	for _, addr := range c.DemerisAddresses {
+		if err := c.NodeInfo.Bech32Config.ValidateAddress(addr, RoleAccount); err != nil {
+			return fmt.Errorf("chain %s: %w", c.ChainName, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package cns_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+)
+
+var cosmosBech32Config = cns.Bech32Config{
+	MainPrefix:      "cosmos",
+	PrefixAccount:   "acc",
+	PrefixValidator: "val",
+	PrefixConsensus: "cons",
+	PrefixPublic:    "pub",
+	PrefixOperator:  "oper",
+}
+
+func TestBech32ConfigPrefix(t *testing.T) {
+	tests := []struct {
+		role     cns.AddressRole
+		expected string
+	}{
+		{cns.RoleAccount, "cosmos"},
+		{cns.RoleAccountPub, "cosmospub"},
+		{cns.RoleValidator, "cosmosvaloper"},
+		{cns.RoleValidatorPub, "cosmosvaloperpub"},
+		{cns.RoleConsensus, "cosmosvalcons"},
+		{cns.RoleConsensusPub, "cosmosvalconspub"},
+	}
+
+	for _, tt := range tests {
+		prefix, err := cosmosBech32Config.Prefix(tt.role)
+		require.NoError(t, err)
+		require.Equal(t, tt.expected, prefix, tt.role.String())
+	}
+
+	_, err := cosmosBech32Config.Prefix(cns.AddressRole(42))
+	require.Error(t, err)
+
+	_, err = cns.Bech32Config{}.Prefix(cns.RoleAccount)
+	require.Error(t, err)
+}
+
+func TestBech32ConfigDecodeAddress(t *testing.T) {
+	tests := []struct {
+		name      string
+		address   string
+		role      cns.AddressRole
+		expected  []byte
+		assertion require.ErrorAssertionFunc
+	}{
+		{
+			"account address",
+			"cosmos1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqnrql8a",
+			cns.RoleAccount,
+			make([]byte, 20),
+			require.NoError,
+		},
+		{
+			"account address used as validator",
+			"cosmos1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqnrql8a",
+			cns.RoleValidator,
+			nil,
+			require.Error,
+		},
+		{
+			"bad checksum",
+			"cosmos1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqnrql8b",
+			cns.RoleAccount,
+			nil,
+			require.Error,
+		},
+		{
+			"other chain prefix",
+			"osmo1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqmcn030",
+			cns.RoleAccount,
+			nil,
+			require.Error,
+		},
+		{
+			"empty string",
+			"",
+			cns.RoleAccount,
+			nil,
+			require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			bz, err := cosmosBech32Config.DecodeAddress(tt.address, tt.role)
+			tt.assertion(t, err)
+			require.Equal(t, tt.expected, bz)
+		})
+	}
+}
+
+func TestBech32ConfigEncodeAddress(t *testing.T) {
+	bz := bytes.Repeat([]byte{0xab}, 20)
+
+	for _, role := range []cns.AddressRole{cns.RoleAccount, cns.RoleValidator, cns.RoleConsensus} {
+		addr, err := cosmosBech32Config.EncodeAddress(bz, role)
+		require.NoError(t, err)
+
+		decoded, err := cosmosBech32Config.DecodeAddress(addr, role)
+		require.NoError(t, err)
+		require.Equal(t, bz, decoded)
+	}
+
+	_, err := cosmosBech32Config.EncodeAddress(nil, cns.RoleAccount)
+	require.Error(t, err)
+
+	_, err = cosmosBech32Config.EncodeAddress(make([]byte, 256), cns.RoleAccount)
+	require.Error(t, err)
+}
+
+func TestChainValidateDemerisAddresses(t *testing.T) {
+	c := cns.Chain{
+		ChainName:        "cosmos-hub",
+		NodeInfo:         cns.NodeInfo{Bech32Config: cosmosBech32Config},
+		DemerisAddresses: []string{"cosmos1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqnrql8a"},
+	}
+	require.NoError(t, c.ValidateDemerisAddresses())
+
+	c.DemerisAddresses = append(c.DemerisAddresses, "osmo1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqmcn030")
+	require.ErrorIs(t, c.ValidateDemerisAddresses(), cns.ErrWrongPrefix)
+}
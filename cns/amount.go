@@ -0,0 +1,219 @@
+package cns
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ErrInvalidAmount is returned when an amount string cannot be parsed.
+var ErrInvalidAmount = errors.New("invalid amount")
+
+// RoundingMode defines how amounts are rounded when digits must be dropped.
+type RoundingMode int
+
+const (
+	// RoundDown rounds towards zero.
+	RoundDown RoundingMode = iota
+	// RoundUp rounds away from zero.
+	RoundUp
+	// RoundHalfUp rounds to the nearest value, with ties rounded away from zero.
+	RoundHalfUp
+	// RoundHalfEven rounds to the nearest value, with ties rounded to the even neighbour.
+	RoundHalfEven
+)
+
+// ToDisplay converts amount, expressed in base units (e.g. uatom), to the display
+// unit (e.g. ATOM) according to d's Precision.
+// The conversion is exact, trailing zeros are trimmed.
+func (d Denom) ToDisplay(amount string) (string, error) {
+	n, err := d.parseBase(amount)
+	if err != nil {
+		return "", err
+	}
+
+	return trimZeros(formatScaled(n, int(d.Precision))), nil
+}
+
+// ToDisplayRounded converts amount, expressed in base units, to the display unit
+// with exactly decimals fractional digits, rounding with mode if needed.
+func (d Denom) ToDisplayRounded(amount string, decimals int, mode RoundingMode) (string, error) {
+	if decimals < 0 {
+		return "", fmt.Errorf("negative decimals %d", decimals)
+	}
+
+	n, err := d.parseBase(amount)
+	if err != nil {
+		return "", err
+	}
+
+	scale := int(d.Precision)
+	if decimals < scale {
+		n = roundScaled(n, scale-decimals, mode)
+	} else {
+		n.Mul(n, pow10(decimals-scale))
+	}
+
+	return formatScaled(n, decimals), nil
+}
+
+// FromDisplay converts amount, expressed in the display unit, to base units according
+// to d's Precision.
+// If amount has more fractional digits than Precision, it is rounded with mode.
+func (d Denom) FromDisplay(amount string, mode RoundingMode) (string, error) {
+	if d.Precision < 0 {
+		return "", fmt.Errorf("denom %s has negative precision %d", d.Name, d.Precision)
+	}
+
+	n, scale, err := parseDecimal(amount)
+	if err != nil {
+		return "", err
+	}
+
+	precision := int(d.Precision)
+	if scale > precision {
+		n = roundScaled(n, scale-precision, mode)
+	} else {
+		n.Mul(n, pow10(precision-scale))
+	}
+
+	return n.String(), nil
+}
+
+// FormatWithTicker converts amount, expressed in base units, to the display unit
+// with decimals fractional digits and appends d's ticker, e.g. "1.50 ATOM".
+// DisplayName and Name are used in place of an empty Ticker.
+func (d Denom) FormatWithTicker(amount string, decimals int, mode RoundingMode) (string, error) {
+	display, err := d.ToDisplayRounded(amount, decimals, mode)
+	if err != nil {
+		return "", err
+	}
+
+	ticker := d.Ticker
+	if ticker == "" {
+		ticker = d.DisplayName
+	}
+
+	if ticker == "" {
+		ticker = d.Name
+	}
+
+	return display + " " + ticker, nil
+}
+
+func (d Denom) parseBase(amount string) (*big.Int, error) {
+	if d.Precision < 0 {
+		return nil, fmt.Errorf("denom %s has negative precision %d", d.Name, d.Precision)
+	}
+
+	n, scale, err := parseDecimal(amount)
+	if err != nil {
+		return nil, err
+	}
+
+	if scale != 0 {
+		return nil, fmt.Errorf("%w: base amount %s must be an integer", ErrInvalidAmount, amount)
+	}
+
+	return n, nil
+}
+
+// parseDecimal parses a plain decimal string such as "-12.345" into its unscaled
+// value and number of fractional digits.
+func parseDecimal(s string) (*big.Int, int, error) {
+	digits := strings.TrimPrefix(s, "-")
+	negative := digits != s
+
+	intPart, fracPart := digits, ""
+	if i := strings.IndexByte(digits, '.'); i >= 0 {
+		intPart, fracPart = digits[:i], digits[i+1:]
+	}
+
+	if intPart == "" || !isDigits(intPart) || !isDigits(fracPart) || strings.HasSuffix(digits, ".") {
+		return nil, 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+
+	n, ok := new(big.Int).SetString(intPart+fracPart, 10)
+	if !ok {
+		return nil, 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+
+	if negative {
+		n.Neg(n)
+	}
+
+	return n, len(fracPart), nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// roundScaled divides n by 10^drop, rounding the result with mode.
+func roundScaled(n *big.Int, drop int, mode RoundingMode) *big.Int {
+	divisor := pow10(drop)
+	q, r := new(big.Int).QuoRem(n, divisor, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+
+	// away is the step that moves q away from zero.
+	away := big.NewInt(int64(n.Sign()))
+
+	switch mode {
+	case RoundUp:
+		return q.Add(q, away)
+	case RoundHalfUp, RoundHalfEven:
+		twice := new(big.Int).Abs(r)
+		twice.Lsh(twice, 1)
+
+		switch twice.Cmp(divisor) {
+		case 1:
+			return q.Add(q, away)
+		case 0:
+			if mode == RoundHalfUp || q.Bit(0) == 1 {
+				return q.Add(q, away)
+			}
+		}
+	}
+
+	return q
+}
+
+// formatScaled formats n as a decimal number with scale fractional digits.
+func formatScaled(n *big.Int, scale int) string {
+	digits := new(big.Int).Abs(n).String()
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+
+	ret := digits
+	if scale > 0 {
+		ret = digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+	}
+
+	if n.Sign() < 0 {
+		ret = "-" + ret
+	}
+
+	return ret
+}
+
+func trimZeros(s string) string {
+	if !strings.Contains(s, ".") {
+		return s
+	}
+
+	return strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
@@ -0,0 +1,127 @@
+package cns_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+)
+
+var atom = cns.Denom{
+	Name:        "uatom",
+	DisplayName: "ATOM",
+	Ticker:      "ATOM",
+	Precision:   6,
+}
+
+func TestDenomToDisplay(t *testing.T) {
+	tests := []struct {
+		name      string
+		denom     cns.Denom
+		amount    string
+		expected  string
+		assertion require.ErrorAssertionFunc
+	}{
+		{"integer amount", atom, "1000000", "1", require.NoError},
+		{"fractional amount", atom, "1234567", "1.234567", require.NoError},
+		{"trailing zeros trimmed", atom, "1500000", "1.5", require.NoError},
+		{"amount smaller than one unit", atom, "42", "0.000042", require.NoError},
+		{"zero", atom, "0", "0", require.NoError},
+		{"negative amount", atom, "-1500000", "-1.5", require.NoError},
+		{"larger than int64", atom, "123456789012345678901234567890", "123456789012345678901234.56789", require.NoError},
+		{"zero precision", cns.Denom{Name: "foo"}, "1234", "1234", require.NoError},
+		{"decimal base amount", atom, "1.5", "", require.Error},
+		{"empty amount", atom, "", "", require.Error},
+		{"exponent notation", atom, "1e6", "", require.Error},
+		{"negative precision", cns.Denom{Precision: -1}, "1", "", require.Error},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := tt.denom.ToDisplay(tt.amount)
+			tt.assertion(t, err)
+			require.Equal(t, tt.expected, res)
+		})
+	}
+}
+
+func TestDenomToDisplayRounded(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   string
+		decimals int
+		mode     cns.RoundingMode
+		expected string
+	}{
+		{"round down", "1234567", 2, cns.RoundDown, "1.23"},
+		{"round up", "1230001", 2, cns.RoundUp, "1.24"},
+		{"round up exact", "1230000", 2, cns.RoundUp, "1.23"},
+		{"half up tie", "1235000", 2, cns.RoundHalfUp, "1.24"},
+		{"half up below tie", "1234999", 2, cns.RoundHalfUp, "1.23"},
+		{"half even tie to even", "1225000", 2, cns.RoundHalfEven, "1.22"},
+		{"half even tie to odd", "1235000", 2, cns.RoundHalfEven, "1.24"},
+		{"half even above tie", "1225001", 2, cns.RoundHalfEven, "1.23"},
+		{"negative round up", "-1230001", 2, cns.RoundUp, "-1.24"},
+		{"negative round down", "-1239999", 2, cns.RoundDown, "-1.23"},
+		{"padding", "1500000", 8, cns.RoundDown, "1.50000000"},
+		{"no decimals", "1500000", 0, cns.RoundHalfEven, "2"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := atom.ToDisplayRounded(tt.amount, tt.decimals, tt.mode)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, res)
+		})
+	}
+
+	_, err := atom.ToDisplayRounded("1", -1, cns.RoundDown)
+	require.Error(t, err)
+}
+
+func TestDenomFromDisplay(t *testing.T) {
+	tests := []struct {
+		name      string
+		amount    string
+		mode      cns.RoundingMode
+		expected  string
+		assertion require.ErrorAssertionFunc
+	}{
+		{"integer", "1", cns.RoundDown, "1000000", require.NoError},
+		{"fractional", "1.234567", cns.RoundDown, "1234567", require.NoError},
+		{"fewer digits than precision", "0.5", cns.RoundDown, "500000", require.NoError},
+		{"more digits than precision, down", "1.2345678", cns.RoundDown, "1234567", require.NoError},
+		{"more digits than precision, up", "1.2345671", cns.RoundUp, "1234568", require.NoError},
+		{"more digits than precision, half up", "0.0000005", cns.RoundHalfUp, "1", require.NoError},
+		{"more digits than precision, half even", "0.0000005", cns.RoundHalfEven, "0", require.NoError},
+		{"negative", "-2.5", cns.RoundDown, "-2500000", require.NoError},
+		{"missing integer part", ".5", cns.RoundDown, "", require.Error},
+		{"missing fractional part", "5.", cns.RoundDown, "", require.Error},
+		{"not a number", "one", cns.RoundDown, "", require.Error},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := atom.FromDisplay(tt.amount, tt.mode)
+			tt.assertion(t, err)
+			require.Equal(t, tt.expected, res)
+		})
+	}
+}
+
+func TestDenomFormatWithTicker(t *testing.T) {
+	res, err := atom.FormatWithTicker("1234567", 2, cns.RoundHalfUp)
+	require.NoError(t, err)
+	require.Equal(t, "1.23 ATOM", res)
+
+	res, err = cns.Denom{Name: "ufoo", Precision: 6}.FormatWithTicker("1000000", 1, cns.RoundDown)
+	require.NoError(t, err)
+	require.Equal(t, "1.0 ufoo", res)
+
+	_, err = atom.FormatWithTicker("foo", 2, cns.RoundDown)
+	require.ErrorIs(t, err, cns.ErrInvalidAmount)
+}
@@ -0,0 +1,187 @@
+package cns
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// bech32 implements the BIP-173 encoding used by Cosmos SDK addresses.
+// See https://github.com/bitcoin/bips/blob/master/bip-0173.mediawiki.
+
+const (
+	bech32Charset      = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+	bech32ChecksumLen  = 6
+	bech32MaxLength    = 1023 // same limit as the Cosmos SDK, which allows for addresses longer than BIP-173
+	bech32MaxAddrBytes = 255  // same limit as the Cosmos SDK address format verification
+)
+
+// ErrInvalidBech32 is returned when a string is not a well-formed bech32 string.
+var ErrInvalidBech32 = errors.New("invalid bech32 string")
+
+var bech32Generator = [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+func bech32Polymod(values []byte) uint32 {
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= bech32Generator[i]
+			}
+		}
+	}
+
+	return chk
+}
+
+func bech32HRPExpand(hrp string) []byte {
+	ret := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		ret = append(ret, hrp[i]>>5)
+	}
+
+	ret = append(ret, 0)
+	for i := 0; i < len(hrp); i++ {
+		ret = append(ret, hrp[i]&31)
+	}
+
+	return ret
+}
+
+func bech32Checksum(hrp string, data []byte) []byte {
+	values := append(bech32HRPExpand(hrp), data...)
+	values = append(values, make([]byte, bech32ChecksumLen)...)
+	mod := bech32Polymod(values) ^ 1
+
+	ret := make([]byte, bech32ChecksumLen)
+	for i := range ret {
+		ret[i] = byte((mod >> uint(5*(5-i))) & 31)
+	}
+
+	return ret
+}
+
+// bech32Encode encodes 5-bit data groups with hrp.
+func bech32Encode(hrp string, data []byte) (string, error) {
+	if hrp == "" {
+		return "", fmt.Errorf("%w: empty human-readable part", ErrInvalidBech32)
+	}
+
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return "", fmt.Errorf("%w: invalid character in human-readable part", ErrInvalidBech32)
+		}
+	}
+
+	hrp = strings.ToLower(hrp)
+
+	var sb strings.Builder
+	sb.WriteString(hrp)
+	sb.WriteByte('1')
+	for _, d := range append(data, bech32Checksum(hrp, data)...) {
+		sb.WriteByte(bech32Charset[d])
+	}
+
+	if sb.Len() > bech32MaxLength {
+		return "", fmt.Errorf("%w: length %d exceeds %d", ErrInvalidBech32, sb.Len(), bech32MaxLength)
+	}
+
+	return sb.String(), nil
+}
+
+// bech32Decode decodes s into its human-readable part and 5-bit data groups.
+func bech32Decode(s string) (string, []byte, error) {
+	if len(s) > bech32MaxLength {
+		return "", nil, fmt.Errorf("%w: length %d exceeds %d", ErrInvalidBech32, len(s), bech32MaxLength)
+	}
+
+	lower := strings.ToLower(s)
+	if lower != s && strings.ToUpper(s) != s {
+		return "", nil, fmt.Errorf("%w: mixed case", ErrInvalidBech32)
+	}
+
+	sep := strings.LastIndexByte(lower, '1')
+	if sep < 1 || sep+bech32ChecksumLen+1 > len(lower) {
+		return "", nil, fmt.Errorf("%w: invalid separator position", ErrInvalidBech32)
+	}
+
+	hrp := lower[:sep]
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return "", nil, fmt.Errorf("%w: invalid character in human-readable part", ErrInvalidBech32)
+		}
+	}
+
+	data := make([]byte, 0, len(lower)-sep-1)
+	for i := sep + 1; i < len(lower); i++ {
+		idx := strings.IndexByte(bech32Charset, lower[i])
+		if idx < 0 {
+			return "", nil, fmt.Errorf("%w: invalid character %q", ErrInvalidBech32, lower[i])
+		}
+
+		data = append(data, byte(idx))
+	}
+
+	if bech32Polymod(append(bech32HRPExpand(hrp), data...)) != 1 {
+		return "", nil, fmt.Errorf("%w: invalid checksum", ErrInvalidBech32)
+	}
+
+	return hrp, data[:len(data)-bech32ChecksumLen], nil
+}
+
+// convertBits regroups data from fromBits-wide groups into toBits-wide groups.
+func convertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, error) {
+	var acc uint32
+	var bits uint
+	maxv := uint32(1)<<toBits - 1
+
+	ret := make([]byte, 0, len(data)*int(fromBits)/int(toBits)+1)
+	for _, v := range data {
+		if uint32(v)>>fromBits != 0 {
+			return nil, fmt.Errorf("%w: invalid data range", ErrInvalidBech32)
+		}
+
+		acc = acc<<fromBits | uint32(v)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			ret = append(ret, byte(acc>>bits&maxv))
+		}
+	}
+
+	switch {
+	case pad && bits > 0:
+		ret = append(ret, byte(acc<<(toBits-bits)&maxv))
+	case !pad && (bits >= fromBits || acc<<(toBits-bits)&maxv != 0):
+		return nil, fmt.Errorf("%w: invalid padding", ErrInvalidBech32)
+	}
+
+	return ret, nil
+}
+
+// bech32EncodeBytes encodes bz as a bech32 string with hrp.
+func bech32EncodeBytes(hrp string, bz []byte) (string, error) {
+	data, err := convertBits(bz, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+
+	return bech32Encode(hrp, data)
+}
+
+// bech32DecodeBytes decodes a bech32 string into its human-readable part and raw bytes.
+func bech32DecodeBytes(s string) (string, []byte, error) {
+	hrp, data, err := bech32Decode(s)
+	if err != nil {
+		return "", nil, err
+	}
+
+	bz, err := convertBits(data, 5, 8, false)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return hrp, bz, nil
+}
@@ -0,0 +1,65 @@
+package cns
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Test vectors from BIP-173.
+func TestBech32Decode(t *testing.T) {
+	valid := []string{
+		"A12UEL5L",
+		"a12uel5l",
+		"an83characterlonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio1tt5tgs",
+		"abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw",
+		"split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w",
+		"?1ezyfcl",
+	}
+
+	for _, s := range valid {
+		s := s
+		t.Run(s, func(t *testing.T) {
+			hrp, data, err := bech32Decode(s)
+			require.NoError(t, err)
+
+			encoded, err := bech32Encode(hrp, data)
+			require.NoError(t, err)
+			require.Equal(t, strings.ToLower(s), encoded)
+		})
+	}
+
+	invalid := []string{
+		"\x201nwldj5",
+		"\x7f1axkwrx",
+		"pzry9x0s0muk",
+		"1pzry9x0s0muk",
+		"x1b4n0q5v",
+		"li1dgmt3",
+		"de1lg7wt\xff",
+		"A1G7SGD8",
+		"10a06t8",
+		"1qzzfhee",
+		"a12UEL5L",
+	}
+
+	for _, s := range invalid {
+		_, _, err := bech32Decode(s)
+		require.ErrorIs(t, err, ErrInvalidBech32, s)
+	}
+}
+
+func TestConvertBits(t *testing.T) {
+	bz := []byte{0x00, 0x14, 0xff, 0x80, 0x01}
+
+	five, err := convertBits(bz, 8, 5, true)
+	require.NoError(t, err)
+
+	eight, err := convertBits(five, 5, 8, false)
+	require.NoError(t, err)
+	require.Equal(t, bz, eight)
+
+	_, err = convertBits([]byte{0x20}, 5, 8, false)
+	require.Error(t, err)
+}
@@ -0,0 +1,117 @@
+package cns
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/mod/semver"
+)
+
+// ErrInvalidSDKVersion is returned when a chain's CosmosSDKVersion is not a valid semver string.
+var ErrInvalidSDKVersion = errors.New("invalid cosmos sdk version")
+
+// Capability represents a feature a chain may or may not support.
+type Capability string
+
+const (
+	// CapabilityStargate marks chains running Cosmos SDK v0.40 or later, with protobuf-based transactions.
+	CapabilityStargate Capability = "stargate"
+	// CapabilityIBCTransfer marks chains supporting ICS-20 token transfers.
+	CapabilityIBCTransfer Capability = "ibc_transfer"
+	// CapabilityLegacyAminoSigning marks chains which only accept amino JSON signatures.
+	CapabilityLegacyAminoSigning Capability = "legacy_amino_signing"
+	// CapabilityAuthz marks chains with the x/authz module.
+	CapabilityAuthz Capability = "authz"
+	// CapabilityFeegrant marks chains with the x/feegrant module.
+	CapabilityFeegrant Capability = "feegrant"
+	// CapabilityGovV1 marks chains with the gov v1 API.
+	CapabilityGovV1 Capability = "gov_v1"
+	// CapabilityLiquidity marks chains with the Gravity DEX x/liquidity module.
+	// The module is not part of the Cosmos SDK, so it must be enabled through overrides.
+	CapabilityLiquidity Capability = "liquidity"
+)
+
+// sdkCapabilities maps capabilities to the Cosmos SDK version range supporting them.
+// An empty bound is unbounded.
+var sdkCapabilities = map[Capability]struct {
+	since  string
+	before string
+}{
+	CapabilityStargate:           {since: "v0.40.0"},
+	CapabilityIBCTransfer:        {since: "v0.40.0"},
+	CapabilityLegacyAminoSigning: {before: "v0.40.0"},
+	CapabilityAuthz:              {since: "v0.43.0"},
+	CapabilityFeegrant:           {since: "v0.43.0"},
+	CapabilityGovV1:              {since: "v0.46.0"},
+}
+
+// AllCapabilities returns all the known capabilities.
+func AllCapabilities() []Capability {
+	return []Capability{
+		CapabilityStargate,
+		CapabilityIBCTransfer,
+		CapabilityLegacyAminoSigning,
+		CapabilityAuthz,
+		CapabilityFeegrant,
+		CapabilityGovV1,
+		CapabilityLiquidity,
+	}
+}
+
+// CapabilityMatrix answers capability questions about chains, based on their Cosmos SDK version.
+// Overrides, keyed by chain name, take precedence over the version-based defaults.
+type CapabilityMatrix struct {
+	Overrides map[string]map[Capability]bool `json:"overrides"`
+}
+
+// Supports returns true if c supports capability.
+func (m CapabilityMatrix) Supports(c Chain, capability Capability) (bool, error) {
+	if v, ok := m.Overrides[c.ChainName][capability]; ok {
+		return v, nil
+	}
+
+	if !semver.IsValid(c.CosmosSDKVersion) {
+		return false, fmt.Errorf("chain %s: %w %q", c.ChainName, ErrInvalidSDKVersion, c.CosmosSDKVersion)
+	}
+
+	if capability == CapabilityLiquidity {
+		return false, nil
+	}
+
+	r, ok := sdkCapabilities[capability]
+	if !ok {
+		return false, fmt.Errorf("unknown capability %s", capability)
+	}
+
+	if r.since != "" && semver.Compare(c.CosmosSDKVersion, r.since) < 0 {
+		return false, nil
+	}
+
+	if r.before != "" && semver.Compare(c.CosmosSDKVersion, r.before) >= 0 {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// Capabilities returns the list of capabilities supported by c.
+func (m CapabilityMatrix) Capabilities(c Chain) ([]Capability, error) {
+	var ret []Capability
+	for _, capability := range AllCapabilities() {
+		ok, err := m.Supports(c, capability)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			ret = append(ret, capability)
+		}
+	}
+
+	return ret, nil
+}
+
+// Supports returns true if c supports capability, based on its Cosmos SDK version only.
+func (c Chain) Supports(capability Capability) (bool, error) {
+	return CapabilityMatrix{}.Supports(c, capability)
+}
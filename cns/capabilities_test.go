@@ -0,0 +1,84 @@
+package cns_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+)
+
+func TestChainSupports(t *testing.T) {
+	tests := []struct {
+		version    string
+		capability cns.Capability
+		expected   bool
+	}{
+		{"v0.39.2", cns.CapabilityStargate, false},
+		{"v0.39.2", cns.CapabilityLegacyAminoSigning, true},
+		{"v0.40.0", cns.CapabilityStargate, true},
+		{"v0.40.0", cns.CapabilityIBCTransfer, true},
+		{"v0.40.0", cns.CapabilityLegacyAminoSigning, false},
+		{"v0.42.11", cns.CapabilityAuthz, false},
+		{"v0.43.0-rc0", cns.CapabilityFeegrant, false},
+		{"v0.43.0", cns.CapabilityFeegrant, true},
+		{"v0.45.4", cns.CapabilityAuthz, true},
+		{"v0.45.4", cns.CapabilityGovV1, false},
+		{"v0.46.0", cns.CapabilityGovV1, true},
+		{"v0.45.4", cns.CapabilityLiquidity, false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.version+"/"+string(tt.capability), func(t *testing.T) {
+			ok, err := cns.Chain{CosmosSDKVersion: tt.version}.Supports(tt.capability)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, ok)
+		})
+	}
+}
+
+func TestChainSupportsErrors(t *testing.T) {
+	_, err := cns.Chain{CosmosSDKVersion: "0.45.4"}.Supports(cns.CapabilityAuthz)
+	require.ErrorIs(t, err, cns.ErrInvalidSDKVersion)
+
+	_, err = cns.Chain{CosmosSDKVersion: "v0.45.4"}.Supports(cns.Capability("foo"))
+	require.Error(t, err)
+}
+
+func TestCapabilityMatrix(t *testing.T) {
+	m := cns.CapabilityMatrix{
+		Overrides: map[string]map[cns.Capability]bool{
+			"cosmos-hub": {
+				cns.CapabilityLiquidity: true,
+				cns.CapabilityAuthz:     false,
+			},
+		},
+	}
+
+	hub := cns.Chain{ChainName: "cosmos-hub", CosmosSDKVersion: "v0.44.5"}
+	caps, err := m.Capabilities(hub)
+	require.NoError(t, err)
+	require.Equal(t, []cns.Capability{
+		cns.CapabilityStargate,
+		cns.CapabilityIBCTransfer,
+		cns.CapabilityFeegrant,
+		cns.CapabilityLiquidity,
+	}, caps)
+
+	osmosis := cns.Chain{ChainName: "osmosis", CosmosSDKVersion: "v0.44.5"}
+	ok, err := m.Supports(osmosis, cns.CapabilityAuthz)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// overrides apply even when the version cannot be parsed
+	ok, err = m.Supports(cns.Chain{ChainName: "cosmos-hub"}, cns.CapabilityLiquidity)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestChainMajorSDKVersion(t *testing.T) {
+	require.Equal(t, "44", cns.Chain{CosmosSDKVersion: "v0.44.3"}.MajorSDKVersion())
+	require.Equal(t, "", cns.Chain{CosmosSDKVersion: "foo"}.MajorSDKVersion())
+	require.Equal(t, "", cns.Chain{}.MajorSDKVersion())
+}
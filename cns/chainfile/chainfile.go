@@ -0,0 +1,392 @@
+// Package chainfile loads CNS chains from YAML or JSON files, one chain per file,
+// and validates them with the same binding rules used by the CNS HTTP API.
+package chainfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"gopkg.in/yaml.v3"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+	"github.com/emerishq/demeris-backend-models/validation"
+)
+
+// Extensions lists the file extensions handled by LoadDir.
+var Extensions = []string{".yaml", ".yml", ".json"}
+
+// FileError is an error annotated with the file, and when known the line and field, it refers to.
+type FileError struct {
+	File  string
+	Line  int
+	Field string
+	Err   error
+}
+
+// Error implements the error interface.
+func (e *FileError) Error() string {
+	var sb strings.Builder
+	sb.WriteString(e.File)
+	if e.Line > 0 {
+		sb.WriteString(":" + strconv.Itoa(e.Line))
+	}
+
+	sb.WriteString(": ")
+	if e.Field != "" {
+		sb.WriteString(e.Field + ": ")
+	}
+
+	sb.WriteString(e.Err.Error())
+
+	return sb.String()
+}
+
+// Unwrap returns the underlying error.
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
+// Errors is a list of FileError, returned when loading more than one file.
+type Errors []*FileError
+
+// Error implements the error interface, one error per line.
+func (e Errors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+// Loader parses and validates chain files.
+type Loader struct {
+	validate *validator.Validate
+}
+
+// NewLoader returns a Loader validating chains with the cns binding rules, including the
+// custom derivationpath, cosmosrpcurl and semver tags. SupportedWallets are validated with
+// the wallet tag as well.
+func NewLoader() *Loader {
+	sv := structValidator{validate: validator.New()}
+	sv.validate.SetTagName("binding")
+
+	validation.CosmosRPCURL(sv)
+	validation.DerivationPath(sv)
+	validation.Semver(sv)
+	validation.Wallet(sv)
+	validation.JSONFields(sv)
+	sv.validate.RegisterStructValidation(validateWallets, cns.Chain{})
+
+	return &Loader{validate: sv.validate}
+}
+
+// validateWallets checks each of a chain's SupportedWallets with the wallet tag, which
+// cns.Chain doesn't declare so that binding consumers aren't required to register it.
+func validateWallets(sl validator.StructLevel) {
+	c, ok := sl.Current().Interface().(cns.Chain)
+	if !ok {
+		return
+	}
+
+	for i, w := range c.SupportedWallets {
+		if err := sl.Validator().Var(w, "wallet"); err != nil {
+			sl.ReportError(w, fmt.Sprintf("supported_wallets[%d]", i), fmt.Sprintf("SupportedWallets[%d]", i), "wallet", "")
+		}
+	}
+}
+
+// LoadDir loads all the chain files with one of Extensions found in dir, sorted by file name.
+// All the files are processed: errors are returned as Errors.
+// Chain names must be unique across files.
+func (l *Loader) LoadDir(dir string) ([]cns.Chain, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		chains []cns.Chain
+		errs   Errors
+		files  = map[string]string{}
+	)
+
+	for _, e := range entries {
+		if e.IsDir() || !hasExtension(e.Name()) {
+			continue
+		}
+
+		path := filepath.Join(dir, e.Name())
+		c, err := l.LoadFile(path)
+		if err != nil {
+			var fileErrs Errors
+			if errors.As(err, &fileErrs) {
+				errs = append(errs, fileErrs...)
+			} else {
+				errs = append(errs, asFileError(path, err))
+			}
+
+			continue
+		}
+
+		if other, ok := files[c.ChainName]; ok {
+			errs = append(errs, &FileError{
+				File:  path,
+				Field: "chain_name",
+				Err:   fmt.Errorf("chain %s already defined in %s", c.ChainName, other),
+			})
+			continue
+		}
+
+		files[c.ChainName] = path
+		chains = append(chains, c)
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return chains, nil
+}
+
+// LoadFile loads and validates the chain defined in path.
+func (l *Loader) LoadFile(path string) (cns.Chain, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cns.Chain{}, &FileError{File: path, Err: err}
+	}
+
+	return l.Parse(path, data)
+}
+
+// Parse parses and validates the chain defined in data. name is used to annotate errors.
+// JSON is parsed as YAML, of which it is a subset, so that both formats report lines.
+func (l *Loader) Parse(name string, data []byte) (cns.Chain, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return cns.Chain{}, &FileError{File: name, Line: yamlErrorLine(err), Err: err}
+	}
+
+	if len(doc.Content) == 0 {
+		return cns.Chain{}, &FileError{File: name, Err: errors.New("empty file")}
+	}
+
+	root := doc.Content[0]
+
+	var raw interface{}
+	if err := root.Decode(&raw); err != nil {
+		return cns.Chain{}, &FileError{File: name, Line: root.Line, Err: err}
+	}
+
+	// cns.Chain is decoded through encoding/json, so that json tags and custom unmarshalers apply.
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return cns.Chain{}, &FileError{File: name, Line: root.Line, Err: err}
+	}
+
+	var c cns.Chain
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&c); err != nil {
+		return cns.Chain{}, decodeError(name, root, err)
+	}
+
+	if err := l.validate.Struct(c); err != nil {
+		return cns.Chain{}, validationError(name, root, err)
+	}
+
+	return c, nil
+}
+
+func decodeError(name string, root *yaml.Node, err error) error {
+	fe := &FileError{File: name, Line: root.Line, Err: err}
+
+	var ute *json.UnmarshalTypeError
+	if errors.As(err, &ute) && ute.Field != "" {
+		fe.Field = ute.Field
+		fe.Line = nodeLine(root, strings.Split(ute.Field, "."))
+		fe.Err = fmt.Errorf("cannot use %s value as %s", ute.Value, ute.Type)
+	}
+
+	// encoding/json reports unknown fields as `json: unknown field "foo"`
+	if msg := err.Error(); strings.HasPrefix(msg, "json: unknown field ") {
+		field, _ := strconv.Unquote(strings.TrimPrefix(msg, "json: unknown field "))
+		fe.Field = field
+		fe.Err = errors.New("unknown field")
+		if n := findKey(root, field); n != nil {
+			fe.Line = n.Line
+		}
+	}
+
+	return fe
+}
+
+func validationError(name string, root *yaml.Node, err error) error {
+	var ve validator.ValidationErrors
+	if !errors.As(err, &ve) {
+		return &FileError{File: name, Line: root.Line, Err: err}
+	}
+
+	errs := make(Errors, 0, len(ve))
+	for _, fe := range ve {
+		// namespaces look like Chain.denoms[0].name, the root struct name is dropped.
+		path := splitNamespace(fe.Namespace())
+		if len(path) > 0 {
+			path = path[1:]
+		}
+
+		errs = append(errs, &FileError{
+			File:  name,
+			Line:  nodeLine(root, path),
+			Field: strings.Join(path, "."),
+			Err:   fmt.Errorf("failed on the %q validation rule", fe.Tag()),
+		})
+	}
+
+	if len(errs) == 1 {
+		return errs[0]
+	}
+
+	sortErrors(errs)
+
+	return errs
+}
+
+// splitNamespace splits a validator namespace into path elements, sequence indexes included.
+func splitNamespace(ns string) []string {
+	var path []string
+	for _, part := range strings.Split(ns, ".") {
+		for {
+			i := strings.IndexByte(part, '[')
+			if i < 0 {
+				break
+			}
+
+			if i > 0 {
+				path = append(path, part[:i])
+			}
+
+			j := strings.IndexByte(part, ']')
+			if j < i {
+				break
+			}
+
+			path = append(path, part[i+1:j])
+			part = part[j+1:]
+		}
+
+		if part != "" {
+			path = append(path, part)
+		}
+	}
+
+	return path
+}
+
+// nodeLine returns the line of the deepest node of path found starting from n.
+func nodeLine(n *yaml.Node, path []string) int {
+	line := n.Line
+	for _, elem := range path {
+		var next *yaml.Node
+		switch n.Kind {
+		case yaml.MappingNode:
+			next = findKey(n, elem)
+		case yaml.SequenceNode:
+			if i, err := strconv.Atoi(elem); err == nil && i >= 0 && i < len(n.Content) {
+				next = n.Content[i]
+			}
+		}
+
+		if next == nil {
+			break
+		}
+
+		n = next
+		line = n.Line
+	}
+
+	return line
+}
+
+// findKey returns the value node for key in mapping node n.
+func findKey(n *yaml.Node, key string) *yaml.Node {
+	if n.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+func yamlErrorLine(err error) int {
+	// yaml.v3 reports errors as "yaml: line N: ..."
+	msg := strings.TrimPrefix(err.Error(), "yaml: line ")
+	if msg == err.Error() {
+		return 0
+	}
+
+	n, convErr := strconv.Atoi(strings.SplitN(msg, ":", 2)[0])
+	if convErr != nil {
+		return 0
+	}
+
+	return n
+}
+
+func asFileError(path string, err error) *FileError {
+	var fe *FileError
+	if errors.As(err, &fe) {
+		return fe
+	}
+
+	return &FileError{File: path, Err: err}
+}
+
+func hasExtension(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	for _, e := range Extensions {
+		if ext == e {
+			return true
+		}
+	}
+
+	return false
+}
+
+// structValidator adapts a validator.Validate to binding.StructValidator, so that the
+// validation package can register its custom tags without gin's global validator.
+type structValidator struct {
+	validate *validator.Validate
+}
+
+func (s structValidator) ValidateStruct(obj interface{}) error {
+	return s.validate.Struct(obj)
+}
+
+func (s structValidator) Engine() interface{} {
+	return s.validate
+}
+
+// sortErrors sorts errs by file and line.
+func sortErrors(errs Errors) {
+	sort.SliceStable(errs, func(i, j int) bool {
+		if errs[i].File != errs[j].File {
+			return errs[i].File < errs[j].File
+		}
+
+		return errs[i].Line < errs[j].Line
+	})
+}
@@ -0,0 +1,109 @@
+package chainfile_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/emerishq/demeris-backend-models/cns/chainfile"
+)
+
+func TestLoaderLoadDir(t *testing.T) {
+	chains, err := chainfile.NewLoader().LoadDir("testdata/valid")
+	require.NoError(t, err)
+	require.Len(t, chains, 2)
+
+	hub := chains[0]
+	require.Equal(t, "cosmos-hub", hub.ChainName)
+	require.Equal(t, "cosmoshub-4", hub.NodeInfo.ChainID)
+	require.Equal(t, "channel-141", hub.PrimaryChannel["osmosis"])
+	require.Equal(t, 10*time.Second, hub.ValidBlockThresh.Duration())
+	require.Equal(t, int64(42), *hub.Denoms[0].MinimumThreshRelayerBalance)
+	require.Equal(t, 0.025, hub.Denoms[0].GasPriceLevels.Average)
+
+	osmosis := chains[1]
+	require.Equal(t, "osmosis", osmosis.ChainName)
+	require.Equal(t, 10*time.Second, osmosis.ValidBlockThresh.Duration())
+	require.Equal(t, []string{"https://rpc.osmosis.zone:443"}, osmosis.PublicNodeEndpoints.TendermintRPC)
+}
+
+func TestLoaderLoadDirErrors(t *testing.T) {
+	_, err := chainfile.NewLoader().LoadDir("testdata/invalid")
+	require.Error(t, err)
+
+	var errs chainfile.Errors
+	require.True(t, errors.As(err, &errs))
+	require.Len(t, errs, 4)
+	require.Equal(t, []string{
+		`testdata/invalid/akash.yaml:38: derivation_path: failed on the "derivationpath" validation rule`,
+		`testdata/invalid/akash.yaml:40: supported_wallets.0: failed on the "wallet" validation rule`,
+		`testdata/invalid/akash.yaml:42: cosmos_sdk_version: failed on the "semver" validation rule`,
+	}, errorStrings(errs[:3]))
+
+	// encoding/json only reports slice indexes in type errors since Go 1.24,
+	// older versions point to the slice itself.
+	require.Regexp(t, `^testdata/invalid/osmosis\.json:(9|13): denoms(\.0)?\.precision: cannot use string value as int64$`, errs[3].Error())
+}
+
+func TestLoaderParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{
+			"malformed yaml",
+			"chain_name: foo\n  bar: [",
+			"chain.yaml:2: yaml: line 2: mapping values are not allowed in this context",
+		},
+		{
+			"empty file",
+			"",
+			"chain.yaml: empty file",
+		},
+		{
+			"unknown field",
+			"chain_name: foo\nfoo: bar\n",
+			"chain.yaml:2: foo: unknown field",
+		},
+		{
+			"missing required field",
+			"chain_name: foo\nlogo: logo.png\ndisplay_name: Foo\ndemeris_addresses: [foo]\ngenesis_hash: abc\n" +
+				"node_info:\n  endpoint: http://foo:26657\n  chain_id: foo-1\n  bech32_config:\n    main_prefix: foo\n    prefix_account: acc\n" +
+				"    prefix_validator: val\n    prefix_consensus: cons\n    prefix_public: pub\n    prefix_operator: oper\n" +
+				"valid_block_thresh: 10s\nderivation_path: m/44'/118'/0'/0/0\nsupported_wallets: [keplr]\n" +
+				"denoms:\n  - display_name: Foo\n" +
+				"cosmos_sdk_version: v0.45.0\n",
+			`chain.yaml:20: denoms.0.name: failed on the "required" validation rule`,
+		},
+		{
+			"invalid public node endpoint",
+			"chain_name: foo\nlogo: logo.png\ndisplay_name: Foo\ndemeris_addresses: [foo]\ngenesis_hash: abc\n" +
+				"node_info:\n  endpoint: http://foo:26657\n  chain_id: foo-1\n  bech32_config:\n    main_prefix: foo\n    prefix_account: acc\n" +
+				"    prefix_validator: val\n    prefix_consensus: cons\n    prefix_public: pub\n    prefix_operator: oper\n" +
+				"valid_block_thresh: 10s\nderivation_path: m/44'/118'/0'/0/0\nsupported_wallets: [keplr]\n" +
+				"public_node_endpoints:\n  tendermint_rpc: [\"tcp://foo:26657\"]\n  cosmos_api: [\"https://foo:1317\"]\n" +
+				"cosmos_sdk_version: v0.45.0\n",
+			`chain.yaml:20: public_node_endpoints.tendermint_rpc.0: failed on the "cosmosrpcurl" validation rule`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := chainfile.NewLoader().Parse("chain.yaml", []byte(tt.data))
+			require.EqualError(t, err, tt.expected)
+		})
+	}
+}
+
+func errorStrings(errs chainfile.Errors) []string {
+	ret := make([]string, 0, len(errs))
+	for _, e := range errs {
+		ret = append(ret, e.Error())
+	}
+
+	return ret
+}
@@ -0,0 +1,288 @@
+// Package chainregistry imports CNS chains from the cosmos chain-registry format.
+// See https://github.com/cosmos/chain-registry.
+package chainregistry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	"golang.org/x/mod/semver"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+)
+
+const (
+	chainFile     = "chain.json"
+	assetListFile = "assetlist.json"
+
+	// Cosmos SDK default bech32 prefixes, chain-registry only defines the main prefix.
+	prefixAccount   = "acc"
+	prefixValidator = "val"
+	prefixConsensus = "cons"
+	prefixPublic    = "pub"
+	prefixOperator  = "oper"
+)
+
+// UnmappedField describes a cns.Chain field that could not be filled from the registry.
+type UnmappedField struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// Result holds an imported chain and the list of fields which could not be mapped.
+type Result struct {
+	Chain    cns.Chain       `json:"chain"`
+	Unmapped []UnmappedField `json:"unmapped"`
+}
+
+// Import reads chain.json and assetlist.json from dir, a chain directory of a checked-out
+// chain-registry, and maps them to a cns.Chain.
+// The returned chain is disabled, and must be reviewed before being stored.
+func Import(dir string) (Result, error) {
+	return ImportFS(os.DirFS(dir), ".")
+}
+
+// ImportFS is like Import, but reads the chain directory dir from fsys.
+func ImportFS(fsys fs.FS, dir string) (Result, error) {
+	var chain Chain
+	if err := readJSON(fsys, path.Join(dir, chainFile), &chain); err != nil {
+		return Result{}, err
+	}
+
+	var assets AssetList
+	err := readJSON(fsys, path.Join(dir, assetListFile), &assets)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		assets = AssetList{}
+	case err != nil:
+		return Result{}, err
+	}
+
+	return Map(chain, assets)
+}
+
+// Map maps a chain-registry chain and its asset list to a cns.Chain.
+func Map(chain Chain, assets AssetList) (Result, error) {
+	if chain.ChainName == "" {
+		return Result{}, errors.New("chain name is empty")
+	}
+
+	if assets.ChainName != "" && assets.ChainName != chain.ChainName {
+		return Result{}, fmt.Errorf("asset list belongs to chain %s, not %s", assets.ChainName, chain.ChainName)
+	}
+
+	m := mapper{}
+	m.res.Chain = cns.Chain{
+		ChainName:   chain.ChainName,
+		DisplayName: chain.PrettyName,
+		Logo:        chain.LogoURIs.url(),
+		NodeInfo: cns.NodeInfo{
+			ChainID: chain.ChainID,
+			Bech32Config: cns.Bech32Config{
+				MainPrefix:      chain.Bech32Prefix,
+				PrefixAccount:   prefixAccount,
+				PrefixValidator: prefixValidator,
+				PrefixConsensus: prefixConsensus,
+				PrefixPublic:    prefixPublic,
+				PrefixOperator:  prefixOperator,
+			},
+		},
+		PrimaryChannel: cns.DbStringMap{},
+	}
+
+	m.required("display_name", chain.PrettyName, "pretty_name not defined")
+	m.required("node_info.chain_id", chain.ChainID, "chain_id not defined")
+	m.required("node_info.bech32_config.main_prefix", chain.Bech32Prefix, "bech32_prefix not defined")
+	m.required("logo", m.res.Chain.Logo, "logo_URIs not defined")
+
+	if chain.Slip44 != nil {
+		m.res.Chain.DerivationPath = fmt.Sprintf("m/44'/%d'/0'/0/0", *chain.Slip44)
+	} else {
+		m.unmapped("derivation_path", "slip44 not defined")
+	}
+
+	m.mapSDKVersion(chain.Codebase.CosmosSDKVersion)
+	m.mapEndpoints(chain.APIs)
+	m.mapDenoms(chain, assets)
+
+	if len(chain.Explorers) > 0 {
+		m.res.Chain.BlockExplorer = chain.Explorers[0].URL
+	}
+
+	m.unmapped("node_info.endpoint", "Emeris-specific node endpoint")
+	m.unmapped("genesis_hash", "chain-registry only publishes the genesis URL")
+	m.unmapped("demeris_addresses", "Emeris-specific fee addresses")
+	m.unmapped("valid_block_thresh", "Emeris-specific liveness threshold")
+	m.unmapped("primary_channel", "defined by the IBC channels, not by the chain")
+	m.unmapped("supported_wallets", "not defined in chain-registry")
+
+	return m.res, nil
+}
+
+type mapper struct {
+	res Result
+}
+
+func (m *mapper) unmapped(field, reason string) {
+	m.res.Unmapped = append(m.res.Unmapped, UnmappedField{Field: field, Reason: reason})
+}
+
+func (m *mapper) required(field, value, reason string) {
+	if value == "" {
+		m.unmapped(field, reason)
+	}
+}
+
+func (m *mapper) mapSDKVersion(version string) {
+	if version == "" {
+		m.unmapped("cosmos_sdk_version", "codebase.cosmos_sdk_version not defined")
+		return
+	}
+
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+
+	if !semver.IsValid(version) {
+		m.unmapped("cosmos_sdk_version", fmt.Sprintf("%s is not a semver version", version))
+		return
+	}
+
+	m.res.Chain.CosmosSDKVersion = version
+}
+
+func (m *mapper) mapEndpoints(apis APIs) {
+	var rpc, rest []string
+	for _, a := range apis.RPC {
+		if u, ok := normalizeURL(a.Address); ok {
+			rpc = append(rpc, u)
+		}
+	}
+
+	for _, a := range apis.REST {
+		if u, ok := normalizeURL(a.Address); ok {
+			rest = append(rest, u)
+		}
+	}
+
+	// both lists must be either filled or empty, see cns.PublicNodeEndpoints binding rules.
+	if len(rpc) == 0 || len(rest) == 0 {
+		m.unmapped("public_node_endpoints", "apis must define both rpc and rest endpoints")
+		return
+	}
+
+	m.res.Chain.PublicNodeEndpoints = cns.PublicNodeEndpoints{
+		TendermintRPC: rpc,
+		CosmosAPI:     rest,
+	}
+}
+
+func (m *mapper) mapDenoms(chain Chain, assets AssetList) {
+	if len(assets.Assets) == 0 {
+		m.unmapped("denoms", "asset list not defined")
+		return
+	}
+
+	feeTokens := map[string]FeeToken{}
+	for _, ft := range chain.Fees.FeeTokens {
+		feeTokens[ft.Denom] = ft
+	}
+
+	stakingTokens := map[string]bool{}
+	for _, st := range chain.Staking.StakingTokens {
+		stakingTokens[st.Denom] = true
+	}
+
+	for _, a := range assets.Assets {
+		if !a.native() {
+			m.unmapped(fmt.Sprintf("denoms.%s", a.Base), "IBC asset, not native to the chain")
+			continue
+		}
+
+		d := cns.Denom{
+			Name:        a.Base,
+			DisplayName: a.Name,
+			Logo:        a.LogoURIs.url(),
+			Ticker:      a.Symbol,
+			PriceID:     a.CoingeckoID,
+			FetchPrice:  a.CoingeckoID != "",
+			Stakable:    stakingTokens[a.Base],
+		}
+
+		precision, ok := a.precision()
+		if ok {
+			d.Precision = precision
+			d.DisplayUnit = a.Display
+		} else {
+			m.unmapped(fmt.Sprintf("denoms.%s.precision", a.Base), fmt.Sprintf("display unit %s not found in denom_units", a.Display))
+		}
+
+		units, err := a.units()
+		if err == nil {
+			d.Units = units
+			err = d.ValidateUnits()
+		}
+
+		if err != nil {
+			m.unmapped(fmt.Sprintf("denoms.%s.units", a.Base), err.Error())
+			d.Units = nil
+		}
+
+		if ft, ok := feeTokens[a.Base]; ok {
+			d.FeeToken = true
+			d.GasPriceLevels = cns.GasPrice{
+				Low:     ft.LowGasPrice,
+				Average: ft.AverageGasPrice,
+				High:    ft.HighGasPrice,
+			}
+
+			if d.GasPriceLevels.Empty() {
+				m.unmapped(fmt.Sprintf("denoms.%s.gas_price_levels", a.Base), "fee token has no gas prices")
+			}
+		}
+
+		m.res.Chain.Denoms = append(m.res.Chain.Denoms, d)
+	}
+}
+
+// normalizeURL adds the default port to u if missing, since cns requires explicit ports.
+func normalizeURL(u string) (string, bool) {
+	parsed, err := url.Parse(strings.TrimSpace(u))
+	if err != nil || parsed.Host == "" {
+		return "", false
+	}
+
+	if parsed.Port() == "" {
+		switch strings.ToLower(parsed.Scheme) {
+		case "https":
+			parsed.Host += ":443"
+		case "http":
+			parsed.Host += ":80"
+		default:
+			return "", false
+		}
+	}
+
+	parsed.Path = strings.TrimSuffix(parsed.Path, "/")
+
+	return parsed.String(), true
+}
+
+func readJSON(fsys fs.FS, name string, dst interface{}) error {
+	b, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(b, dst); err != nil {
+		return fmt.Errorf("cannot parse %s, %w", name, err)
+	}
+
+	return nil
+}
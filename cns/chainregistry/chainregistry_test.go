@@ -0,0 +1,183 @@
+package chainregistry_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+	"github.com/emerishq/demeris-backend-models/cns/chainregistry"
+)
+
+func TestImport(t *testing.T) {
+	res, err := chainregistry.Import("testdata/cosmoshub")
+	require.NoError(t, err)
+
+	coinType, err := res.Chain.CoinType()
+	require.NoError(t, err)
+	require.Equal(t, uint32(118), coinType)
+
+	require.Equal(t, cns.Chain{
+		ChainName:      "cosmoshub",
+		DisplayName:    "Cosmos Hub",
+		Logo:           "https://raw.githubusercontent.com/cosmos/chain-registry/master/cosmoshub/images/atom.png",
+		PrimaryChannel: cns.DbStringMap{},
+		Denoms: cns.DenomList{
+			{
+				Name:           "uatom",
+				DisplayName:    "Cosmos Hub Atom",
+				Logo:           "https://raw.githubusercontent.com/cosmos/chain-registry/master/cosmoshub/images/atom.png",
+				Precision:      6,
+				Stakable:       true,
+				Ticker:         "ATOM",
+				PriceID:        "cosmos",
+				FeeToken:       true,
+				GasPriceLevels: cns.GasPrice{Low: 0.01, Average: 0.025, High: 0.03},
+				FetchPrice:     true,
+				Units:          cns.DenomUnits{{Denom: "uatom"}, {Denom: "atom", Exponent: 6}},
+				DisplayUnit:    "atom",
+			},
+		},
+		NodeInfo: cns.NodeInfo{
+			ChainID: "cosmoshub-4",
+			Bech32Config: cns.Bech32Config{
+				MainPrefix:      "cosmos",
+				PrefixAccount:   "acc",
+				PrefixValidator: "val",
+				PrefixConsensus: "cons",
+				PrefixPublic:    "pub",
+				PrefixOperator:  "oper",
+			},
+		},
+		DerivationPath: "m/44'/118'/0'/0/0",
+		BlockExplorer:  "https://www.mintscan.io/cosmos",
+		PublicNodeEndpoints: cns.PublicNodeEndpoints{
+			TendermintRPC: []string{"https://rpc-cosmoshub.blockapsis.com:443", "http://rpc.cosmos.network:26657"},
+			CosmosAPI:     []string{"https://lcd-cosmoshub.blockapsis.com:443"},
+		},
+		CosmosSDKVersion: "v0.45.4",
+	}, res.Chain)
+
+	var unmapped []string
+	for _, u := range res.Unmapped {
+		unmapped = append(unmapped, u.Field)
+	}
+
+	require.ElementsMatch(t, []string{
+		"node_info.endpoint",
+		"genesis_hash",
+		"demeris_addresses",
+		"valid_block_thresh",
+		"primary_channel",
+		"supported_wallets",
+		"denoms.ibc/14F9BC3E44B8A9C1BE1FB08980FAB87034C9905EF17CF2F5008FC085218811CC",
+	}, unmapped)
+}
+
+func TestImportFS(t *testing.T) {
+	tests := []struct {
+		name      string
+		fsys      fstest.MapFS
+		unmapped  []string
+		assertion require.ErrorAssertionFunc
+	}{
+		{
+			"minimal chain without asset list",
+			fstest.MapFS{
+				"foo/chain.json": {Data: []byte(`{"chain_name": "foo", "codebase": {"cosmos_sdk_version": "not-a-version"}}`)},
+			},
+			[]string{
+				"display_name",
+				"node_info.chain_id",
+				"node_info.bech32_config.main_prefix",
+				"logo",
+				"derivation_path",
+				"cosmos_sdk_version",
+				"public_node_endpoints",
+				"denoms",
+				"node_info.endpoint",
+				"genesis_hash",
+				"demeris_addresses",
+				"valid_block_thresh",
+				"primary_channel",
+				"supported_wallets",
+			},
+			require.NoError,
+		},
+		{
+			"asset traced through IBC",
+			fstest.MapFS{
+				"foo/chain.json":     {Data: []byte(`{"chain_name": "foo", "pretty_name": "Foo", "chain_id": "foo-1", "bech32_prefix": "foo", "slip44": 118, "codebase": {"cosmos_sdk_version": "v0.45.4"}, "logo_URIs": {"png": "https://foo/foo.png"}, "apis": {"rpc": [{"address": "https://rpc.foo:443"}], "rest": [{"address": "https://api.foo:443"}]}}`)},
+				"foo/assetlist.json": {Data: []byte(`{"chain_name": "foo", "assets": [{"base": "cw20:juno1abc", "display": "cw20:juno1abc", "denom_units": [{"denom": "cw20:juno1abc"}], "traces": [{"type": "ibc-cw20", "counterparty": {"chain_name": "juno", "base_denom": "cw20:juno1abc"}}]}]}`)},
+			},
+			[]string{
+				"denoms.cw20:juno1abc",
+				"node_info.endpoint",
+				"genesis_hash",
+				"demeris_addresses",
+				"valid_block_thresh",
+				"primary_channel",
+				"supported_wallets",
+			},
+			require.NoError,
+		},
+		{
+			"inconsistent denom units",
+			fstest.MapFS{
+				"foo/chain.json":     {Data: []byte(`{"chain_name": "foo", "pretty_name": "Foo", "chain_id": "foo-1", "bech32_prefix": "foo", "slip44": 118, "codebase": {"cosmos_sdk_version": "v0.45.4"}, "logo_URIs": {"png": "https://foo/foo.png"}, "apis": {"rpc": [{"address": "https://rpc.foo:443"}], "rest": [{"address": "https://api.foo:443"}]}}`)},
+				"foo/assetlist.json": {Data: []byte(`{"chain_name": "foo", "assets": [{"base": "ufoo", "display": "foo", "denom_units": [{"denom": "ufoo"}, {"denom": "foo", "exponent": 6}, {"denom": "foo", "exponent": 9}]}]}`)},
+			},
+			[]string{
+				"denoms.ufoo.units",
+				"node_info.endpoint",
+				"genesis_hash",
+				"demeris_addresses",
+				"valid_block_thresh",
+				"primary_channel",
+				"supported_wallets",
+			},
+			require.NoError,
+		},
+		{
+			"missing chain.json",
+			fstest.MapFS{
+				"foo/assetlist.json": {Data: []byte(`{"chain_name": "foo"}`)},
+			},
+			nil,
+			require.Error,
+		},
+		{
+			"malformed chain.json",
+			fstest.MapFS{
+				"foo/chain.json": {Data: []byte(`{`)},
+			},
+			nil,
+			require.Error,
+		},
+		{
+			"asset list of another chain",
+			fstest.MapFS{
+				"foo/chain.json":     {Data: []byte(`{"chain_name": "foo"}`)},
+				"foo/assetlist.json": {Data: []byte(`{"chain_name": "bar"}`)},
+			},
+			nil,
+			require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := chainregistry.ImportFS(tt.fsys, "foo")
+			tt.assertion(t, err)
+
+			var unmapped []string
+			for _, u := range res.Unmapped {
+				unmapped = append(unmapped, u.Field)
+			}
+
+			require.Equal(t, tt.unmapped, unmapped)
+		})
+	}
+}
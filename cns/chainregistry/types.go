@@ -0,0 +1,172 @@
+package chainregistry
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+)
+
+// Chain is the subset of the chain-registry chain.json schema used by the importer.
+type Chain struct {
+	ChainName    string     `json:"chain_name"`
+	PrettyName   string     `json:"pretty_name"`
+	ChainID      string     `json:"chain_id"`
+	Bech32Prefix string     `json:"bech32_prefix"`
+	Slip44       *uint32    `json:"slip44"`
+	Fees         Fees       `json:"fees"`
+	Staking      Staking    `json:"staking"`
+	Codebase     Codebase   `json:"codebase"`
+	APIs         APIs       `json:"apis"`
+	Explorers    []Explorer `json:"explorers"`
+	LogoURIs     LogoURIs   `json:"logo_URIs"`
+}
+
+// Fees holds the fee tokens accepted by a chain.
+type Fees struct {
+	FeeTokens []FeeToken `json:"fee_tokens"`
+}
+
+// FeeToken represents a denom usable as fee and its gas prices.
+type FeeToken struct {
+	Denom            string  `json:"denom"`
+	FixedMinGasPrice float64 `json:"fixed_min_gas_price"`
+	LowGasPrice      float64 `json:"low_gas_price"`
+	AverageGasPrice  float64 `json:"average_gas_price"`
+	HighGasPrice     float64 `json:"high_gas_price"`
+}
+
+// Staking holds the staking tokens of a chain.
+type Staking struct {
+	StakingTokens []StakingToken `json:"staking_tokens"`
+}
+
+// StakingToken represents a denom usable for staking.
+type StakingToken struct {
+	Denom string `json:"denom"`
+}
+
+// Codebase holds information about the chain's software.
+type Codebase struct {
+	RecommendedVersion string `json:"recommended_version"`
+	CosmosSDKVersion   string `json:"cosmos_sdk_version"`
+}
+
+// APIs holds the public endpoints of a chain.
+type APIs struct {
+	RPC  []Endpoint `json:"rpc"`
+	REST []Endpoint `json:"rest"`
+	GRPC []Endpoint `json:"grpc"`
+}
+
+// Endpoint represents a public endpoint.
+type Endpoint struct {
+	Address  string `json:"address"`
+	Provider string `json:"provider"`
+}
+
+// Explorer represents a block explorer.
+type Explorer struct {
+	Kind   string `json:"kind"`
+	URL    string `json:"url"`
+	TxPage string `json:"tx_page"`
+}
+
+// LogoURIs holds the URIs of a logo in different formats.
+type LogoURIs struct {
+	PNG string `json:"png"`
+	SVG string `json:"svg"`
+}
+
+// url returns the preferred logo URI, PNG first.
+func (l LogoURIs) url() string {
+	if l.PNG != "" {
+		return l.PNG
+	}
+
+	return l.SVG
+}
+
+// AssetList is the chain-registry assetlist.json schema.
+type AssetList struct {
+	ChainName string  `json:"chain_name"`
+	Assets    []Asset `json:"assets"`
+}
+
+// Asset represents a chain-registry asset.
+type Asset struct {
+	Description string      `json:"description"`
+	DenomUnits  []DenomUnit `json:"denom_units"`
+	Base        string      `json:"base"`
+	Name        string      `json:"name"`
+	Display     string      `json:"display"`
+	Symbol      string      `json:"symbol"`
+	LogoURIs    LogoURIs    `json:"logo_URIs"`
+	CoingeckoID string      `json:"coingecko_id"`
+	TypeAsset   string      `json:"type_asset"`
+	Traces      []Trace     `json:"traces"`
+}
+
+// Trace represents the origin of an asset, e.g. an IBC transfer from another chain.
+type Trace struct {
+	Type         string            `json:"type"`
+	Counterparty TraceCounterparty `json:"counterparty"`
+}
+
+// TraceCounterparty identifies the asset an asset is traced back to.
+type TraceCounterparty struct {
+	ChainName string `json:"chain_name"`
+	BaseDenom string `json:"base_denom"`
+}
+
+// DenomUnit represents a unit of an asset.
+type DenomUnit struct {
+	Denom    string   `json:"denom"`
+	Exponent int64    `json:"exponent"`
+	Aliases  []string `json:"aliases"`
+}
+
+// native returns false if a is an IBC voucher of an asset of another chain.
+func (a Asset) native() bool {
+	if strings.HasPrefix(a.Base, "ibc/") || a.TypeAsset == "ics20" {
+		return false
+	}
+
+	for _, t := range a.Traces {
+		if strings.HasPrefix(t.Type, "ibc") {
+			return false
+		}
+	}
+
+	return true
+}
+
+// units returns a's denom units as cns.DenomUnits.
+func (a Asset) units() (cns.DenomUnits, error) {
+	ret := make(cns.DenomUnits, 0, len(a.DenomUnits))
+	for _, du := range a.DenomUnits {
+		if du.Exponent < 0 || du.Exponent > math.MaxUint32 {
+			return nil, fmt.Errorf("unit %s exponent %d out of range", du.Denom, du.Exponent)
+		}
+
+		ret = append(ret, cns.DenomUnit{
+			Denom:    du.Denom,
+			Exponent: uint32(du.Exponent),
+			Aliases:  du.Aliases,
+		})
+	}
+
+	return ret, nil
+}
+
+// precision returns the exponent of a's display unit.
+func (a Asset) precision() (int64, bool) {
+	for _, du := range a.DenomUnits {
+		if du.Denom == a.Display {
+			return du.Exponent, true
+		}
+	}
+
+	return 0, false
+}
@@ -3,7 +3,6 @@ package cns
 import (
 	"database/sql/driver"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -14,7 +13,7 @@ import (
 
 // Chain represents CNS chain metadata row on the database.
 type Chain struct {
-	ID                  uint64              `diff:"-" db:"id" json:"-"`
+	ID                  uint64              `diff:"-" audit:"-" db:"id" json:"-"`
 	Enabled             bool                `diff:"-" db:"enabled" json:"enabled"`                                                                          // boolean that marks whether the given chain is enabled or not (when enabled, API endpoints will return data)
 	ChainName           string              `db:"chain_name" binding:"required" json:"chain_name"`                                                          // the unique name of the chain
 	Logo                string              `diff:"-" db:"logo" binding:"required" json:"logo"`                                                             // logo of the chain
@@ -26,7 +25,7 @@ type Chain struct {
 	NodeInfo            NodeInfo            `diff:"-" db:"node_info" binding:"required,dive" json:"node_info"`                                              // info required to query full-node (e.g. to submit tx)
 	ValidBlockThresh    Threshold           `diff:"-" db:"valid_block_thresh" binding:"required" json:"valid_block_thresh" swaggertype:"primitive,integer"` // valid block time expressed in time.Duration format
 	DerivationPath      string              `diff:"-" db:"derivation_path" binding:"required,derivationpath" json:"derivation_path"`                        // chain derivation path
-	SupportedWallets    pq.StringArray      `diff:"-" db:"supported_wallets" binding:"required" json:"supported_wallets"`                                   // the list of supported wallets
+	SupportedWallets    pq.StringArray      `diff:"-" db:"supported_wallets" binding:"required" json:"supported_wallets"`                                   // the list of supported wallets, see Wallet
 	BlockExplorer       string              `diff:"-" db:"block_explorer" json:"block_explorer"`                                                            // block explorer url
 	PublicNodeEndpoints PublicNodeEndpoints `diff:"-" db:"public_node_endpoints" binding:"dive" json:"public_node_endpoints,omitempty"`                     // endpoints for non-natively supported chains
 	CosmosSDKVersion    string              `diff:"-" db:"cosmos_sdk_version" binding:"required,semver" json:"cosmos_sdk_version,omitempty"`                // Cosmos SDK version used by the chain
@@ -61,6 +60,8 @@ func (c Chain) FeeTokens() DenomList {
 }
 
 // RelayerToken returns the relayer token for a given chain.
+// It panics if no relayer token is defined, and returns the first one if many are defined:
+// use FindRelayerToken to handle misconfigured chains.
 func (c Chain) RelayerToken() Denom {
 	for _, ft := range c.Denoms {
 		if ft.RelayerDenom {
@@ -71,33 +72,51 @@ func (c Chain) RelayerToken() Denom {
 	panic("relayer token not defined")
 }
 
+// MajorSDKVersion returns the minor component of c's CosmosSDKVersion, e.g. "44" for v0.44.3,
+// which identifies the release line of v0 Cosmos SDK versions.
+// An empty string is returned if CosmosSDKVersion is not a valid semver string.
 func (c Chain) MajorSDKVersion() string {
 	rawVersion := semver.MajorMinor(c.CosmosSDKVersion)
-	return strings.Split(rawVersion, ".")[1]
+	parts := strings.Split(rawVersion, ".")
+	if len(parts) < 2 {
+		return ""
+	}
+
+	return parts[1]
 }
 
 // Threshold is a database-friendly time.Duration.
 type Threshold time.Duration
 
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Strings are parsed with ParseThreshold, numbers are interpreted as nanoseconds.
 func (t *Threshold) UnmarshalJSON(bytes []byte) error {
+	var ns int64
+	if err := json.Unmarshal(bytes, &ns); err == nil {
+		*t = Threshold(ns)
+		return nil
+	}
+
 	str := ""
 
 	if err := json.Unmarshal(bytes, &str); err != nil {
 		return err
 	}
 
-	d, err := time.ParseDuration(str)
+	d, err := ParseThreshold(str)
 	if err != nil {
 		return err
 	}
 
-	*t = Threshold(d)
+	*t = d
 
 	return nil
 }
 
+// MarshalJSON implements the json.Marshaler interface, using ThresholdFormatGo.
+// See FormattedThreshold for other formats.
 func (t Threshold) MarshalJSON() ([]byte, error) {
-	return json.Marshal(t.Duration().String())
+	return json.Marshal(t.Format(ThresholdFormatGo))
 }
 
 // Duration returns t as time.Duration.
@@ -106,26 +125,35 @@ func (t Threshold) Duration() time.Duration {
 }
 
 // Scan is the sql.Scanner implementation for Threshold.
+// Strings and byte slices are parsed with ParseThreshold, integers are interpreted as nanoseconds.
 func (t *Threshold) Scan(value interface{}) error {
-	vs, ok := value.(string)
-	if !ok {
-		return fmt.Errorf("threshold value is of type %T, not string", value)
+	var vs string
+	switch v := value.(type) {
+	case string:
+		vs = v
+	case []byte:
+		vs = string(v)
+	case int64:
+		*t = Threshold(v)
+		return nil
+	default:
+		return fmt.Errorf("threshold value is of type %T, not string, []byte or int64", value)
 	}
 
-	vsd, err := time.ParseDuration(vs)
+	vsd, err := ParseThreshold(vs)
 	if err != nil {
 		return fmt.Errorf("cannot parse value as duration, %w", err)
 	}
 
-	*t = Threshold(vsd)
+	*t = vsd
 
 	return nil
 }
 
-// Value is the driver.Value implementation for Threshold.
+// Value is the driver.Value implementation for Threshold, using ThresholdFormatGo.
+// See FormattedThreshold for other formats.
 func (t Threshold) Value() (driver.Value, error) {
-	td := time.Duration(t)
-	return driver.Value(td.String()), nil
+	return driver.Value(t.Format(ThresholdFormatGo)), nil
 }
 
 // NodeInfo holds information useful to connect to a full node and broadcast transactions.
@@ -135,19 +163,14 @@ type NodeInfo struct {
 	Bech32Config Bech32Config `binding:"required,dive" json:"bech32_config"`
 }
 
-// Scan is the sql.Scanner implementation for DbStringMap.
+// Scan is the sql.Scanner implementation for NodeInfo.
 func (a *NodeInfo) Scan(value interface{}) error {
-	b, ok := value.([]byte)
-	if !ok {
-		return errors.New("type assertion to []byte failed")
-	}
-
-	err := json.Unmarshal(b, &a)
-	if err != nil {
-		return err
-	}
+	return scanJSONB(value, a)
+}
 
-	return nil
+// Value is the driver.Valuer implementation for NodeInfo.
+func (a NodeInfo) Value() (driver.Value, error) {
+	return NewJSONB(a).Value()
 }
 
 // PublicNodeEndpoints holds information for experimental chains, i.e. not natively supported by our wallets.
@@ -159,17 +182,12 @@ type PublicNodeEndpoints struct {
 
 // Scan is the sql.Scanner implementation for PublicNodeEndpoints.
 func (a *PublicNodeEndpoints) Scan(value interface{}) error {
-	b, ok := value.([]byte)
-	if !ok {
-		return errors.New("type assertion to []byte failed")
-	}
-
-	err := json.Unmarshal(b, &a)
-	if err != nil {
-		return err
-	}
+	return scanJSONB(value, a)
+}
 
-	return nil
+// Value is the driver.Valuer implementation for PublicNodeEndpoints.
+func (a PublicNodeEndpoints) Value() (driver.Value, error) {
+	return NewJSONB(a).Value()
 }
 
 // GasPrice holds gas prices.
@@ -183,19 +201,14 @@ func (a GasPrice) Empty() bool {
 	return a == GasPrice{}
 }
 
-// Scan is the sql.Scanner implementation for DbStringMap.
+// Scan is the sql.Scanner implementation for GasPrice.
 func (a *GasPrice) Scan(value interface{}) error {
-	b, ok := value.([]byte)
-	if !ok {
-		return errors.New("type assertion to []byte failed")
-	}
-
-	err := json.Unmarshal(b, &a)
-	if err != nil {
-		return err
-	}
+	return scanJSONB(value, a)
+}
 
-	return nil
+// Value is the driver.Valuer implementation for GasPrice.
+func (a GasPrice) Value() (driver.Value, error) {
+	return NewJSONB(a).Value()
 }
 
 // Bech32Config represents the chain's bech32 configuration
@@ -271,20 +284,23 @@ type bech32ConfigMarshaled struct {
 }
 
 // Denom holds a token denomination and its verification status.
+// Denom is not comparable since it holds Units, use reflect.DeepEqual or compare Name instead.
 type Denom struct {
-	Name                        string   `db:"name" binding:"required" json:"name,omitempty"`
-	DisplayName                 string   `db:"display_name" json:"display_name"`
-	Logo                        string   `db:"logo" json:"logo,omitempty"`
-	Precision                   int64    `db:"precision" json:"precision,omitempty"`
-	Verified                    bool     `db:"verified" json:"verified,omitempty"`
-	Stakable                    bool     `db:"stakable" json:"stakable,omitempty"`
-	Ticker                      string   `db:"ticker" json:"ticker,omitempty"`
-	PriceID                     string   `db:"price_id" json:"price_id,omitempty"`
-	FeeToken                    bool     `db:"fee_token" json:"fee_token,omitempty"`
-	GasPriceLevels              GasPrice `db:"gas_price_levels" json:"gas_price_levels"`
-	FetchPrice                  bool     `db:"fetch_price" json:"fetch_price"`
-	RelayerDenom                bool     `db:"relayer_denom" json:"relayer_denom"`
-	MinimumThreshRelayerBalance *int64   `db:"minimum_thresh_relayer_balance" json:"minimum_thresh_relayer_balance,omitempty"`
+	Name                        string     `audit:"name,identifier" db:"name" binding:"required" json:"name,omitempty"`
+	DisplayName                 string     `db:"display_name" json:"display_name"`
+	Logo                        string     `db:"logo" json:"logo,omitempty"`
+	Precision                   int64      `db:"precision" json:"precision,omitempty"`
+	Verified                    bool       `db:"verified" json:"verified,omitempty"`
+	Stakable                    bool       `db:"stakable" json:"stakable,omitempty"`
+	Ticker                      string     `db:"ticker" json:"ticker,omitempty"`
+	PriceID                     string     `db:"price_id" json:"price_id,omitempty"`
+	FeeToken                    bool       `db:"fee_token" json:"fee_token,omitempty"`
+	GasPriceLevels              GasPrice   `db:"gas_price_levels" json:"gas_price_levels"`
+	FetchPrice                  bool       `db:"fetch_price" json:"fetch_price"`
+	RelayerDenom                bool       `db:"relayer_denom" json:"relayer_denom"`
+	MinimumThreshRelayerBalance *int64     `db:"minimum_thresh_relayer_balance" json:"minimum_thresh_relayer_balance,omitempty"`
+	Units                       DenomUnits `db:"units" json:"units,omitempty"`               // bank metadata denom units, base unit included
+	DisplayUnit                 string     `db:"display_unit" json:"display_unit,omitempty"` // unit amounts are displayed in, with Precision as exponent
 }
 
 // DenomList represents a slice of Denom.
@@ -292,17 +308,12 @@ type DenomList []Denom
 
 // Scan is the sql.Scanner implementation for DenomList.
 func (a *DenomList) Scan(value interface{}) error {
-	b, ok := value.([]byte)
-	if !ok {
-		return errors.New("type assertion to []byte failed")
-	}
-
-	err := json.Unmarshal(b, &a)
-	if err != nil {
-		return err
-	}
+	return scanJSONB(value, a)
+}
 
-	return nil
+// Value is the driver.Valuer implementation for DenomList.
+func (a DenomList) Value() (driver.Value, error) {
+	return NewJSONB(a).Value()
 }
 
 // DbStringMap represent a JSON database-enabled string map.
@@ -310,17 +321,12 @@ type DbStringMap map[string]string
 
 // Scan is the sql.Scanner implementation for DbStringMap.
 func (a *DbStringMap) Scan(value interface{}) error {
-	b, ok := value.([]byte)
-	if !ok {
-		return errors.New("type assertion to []byte failed")
-	}
-
-	err := json.Unmarshal(b, &a)
-	if err != nil {
-		return err
-	}
+	return scanJSONB(value, a)
+}
 
-	return nil
+// Value is the driver.Valuer implementation for DbStringMap.
+func (a DbStringMap) Value() (driver.Value, error) {
+	return NewJSONB(a).Value()
 }
 
 // ChannelQuery represents a query to get a specified channel or counterparty data.
@@ -0,0 +1,69 @@
+package cns
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrCoinTypeMismatch is returned when converting an address between chains that derive keys
+// with different coin types.
+var ErrCoinTypeMismatch = errors.New("coin type mismatch")
+
+// ConvertAddress converts an account address of the from chain into the equivalent account
+// address of the to chain, e.g. cosmos1... to osmo1...
+// Both chains must share the same coin type, otherwise the same key would not map to both addresses.
+func ConvertAddress(address string, from, to Chain) (string, error) {
+	fromCoinType, err := from.CoinType()
+	if err != nil {
+		return "", err
+	}
+
+	toCoinType, err := to.CoinType()
+	if err != nil {
+		return "", err
+	}
+
+	if fromCoinType != toCoinType {
+		return "", fmt.Errorf("%w: chain %s uses coin type %d, chain %s uses coin type %d",
+			ErrCoinTypeMismatch, from.ChainName, fromCoinType, to.ChainName, toCoinType)
+	}
+
+	bz, err := from.NodeInfo.Bech32Config.DecodeAddress(address, RoleAccount)
+	if err != nil {
+		return "", err
+	}
+
+	return to.NodeInfo.Bech32Config.EncodeAddress(bz, RoleAccount)
+}
+
+// ConvertAddressToAll converts an account address of the from chain into the equivalent account
+// address of each chain in to sharing its coin type, keyed by chain name.
+// Chains with a different coin type or an invalid configuration are skipped.
+func ConvertAddressToAll(address string, from Chain, to []Chain) (map[string]string, error) {
+	bz, err := from.NodeInfo.Bech32Config.DecodeAddress(address, RoleAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	fromCoinType, err := from.CoinType()
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string]string, len(to))
+	for _, c := range to {
+		coinType, err := c.CoinType()
+		if err != nil || coinType != fromCoinType {
+			continue
+		}
+
+		addr, err := c.NodeInfo.Bech32Config.EncodeAddress(bz, RoleAccount)
+		if err != nil {
+			continue
+		}
+
+		ret[c.ChainName] = addr
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,113 @@
+package cns_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+)
+
+func testChain(name, prefix, derivationPath string) cns.Chain {
+	return cns.Chain{
+		ChainName:      name,
+		DerivationPath: derivationPath,
+		NodeInfo: cns.NodeInfo{
+			Bech32Config: cns.Bech32Config{
+				MainPrefix:      prefix,
+				PrefixAccount:   "acc",
+				PrefixValidator: "val",
+				PrefixConsensus: "cons",
+				PrefixPublic:    "pub",
+				PrefixOperator:  "oper",
+			},
+		},
+	}
+}
+
+var (
+	cosmosHub = testChain("cosmos-hub", "cosmos", "m/44'/118'/0'/0/0")
+	osmosis   = testChain("osmosis", "osmo", "m/44'/118'/0'/0/0")
+	cryptoOrg = testChain("crypto-org", "cro", "m/44'/394'/0'/0/0")
+)
+
+func TestChainCoinType(t *testing.T) {
+	ct, err := cosmosHub.CoinType()
+	require.NoError(t, err)
+	require.Equal(t, uint32(118), ct)
+
+	ct, err = cryptoOrg.CoinType()
+	require.NoError(t, err)
+	require.Equal(t, uint32(394), ct)
+
+	_, err = testChain("foo", "foo", "0/1").CoinType()
+	require.Error(t, err)
+
+	_, err = testChain("foo", "foo", "m/foo").CoinType()
+	require.Error(t, err)
+}
+
+func TestConvertAddress(t *testing.T) {
+	tests := []struct {
+		name      string
+		address   string
+		from      cns.Chain
+		to        cns.Chain
+		expected  string
+		assertion require.ErrorAssertionFunc
+	}{
+		{
+			"cosmos to osmosis",
+			"cosmos1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqnrql8a",
+			cosmosHub,
+			osmosis,
+			"osmo1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqmcn030",
+			require.NoError,
+		},
+		{
+			"osmosis to cosmos",
+			"osmo1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqmcn030",
+			osmosis,
+			cosmosHub,
+			"cosmos1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqnrql8a",
+			require.NoError,
+		},
+		{
+			"different coin types",
+			"cosmos1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqnrql8a",
+			cosmosHub,
+			cryptoOrg,
+			"",
+			require.Error,
+		},
+		{
+			"address not belonging to source chain",
+			"osmo1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqmcn030",
+			cosmosHub,
+			osmosis,
+			"",
+			require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := cns.ConvertAddress(tt.address, tt.from, tt.to)
+			tt.assertion(t, err)
+			require.Equal(t, tt.expected, res)
+		})
+	}
+
+	_, err := cns.ConvertAddress("cosmos1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqnrql8a", cosmosHub, cryptoOrg)
+	require.ErrorIs(t, err, cns.ErrCoinTypeMismatch)
+}
+
+func TestConvertAddressToAll(t *testing.T) {
+	res, err := cns.ConvertAddressToAll("cosmos1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqnrql8a", cosmosHub, []cns.Chain{cosmosHub, osmosis, cryptoOrg})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"cosmos-hub": "cosmos1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqnrql8a",
+		"osmosis":    "osmo1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqmcn030",
+	}, res)
+}
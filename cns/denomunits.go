@@ -0,0 +1,250 @@
+package cns
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownDenomUnit is returned when a unit is not defined for a denom.
+var ErrUnknownDenomUnit = errors.New("unknown denom unit")
+
+// DenomUnit is a unit of a denom, as defined by the bank module metadata, e.g. matom is
+// 10^3 uatom.
+type DenomUnit struct {
+	Denom    string   `audit:"denom,identifier" json:"denom"`
+	Exponent uint32   `json:"exponent"` // power of 10 of the unit, in base units
+	Aliases  []string `json:"aliases,omitempty"`
+}
+
+// DenomUnits represents a slice of DenomUnit.
+type DenomUnits []DenomUnit
+
+// Find returns the unit named or aliased name.
+func (u DenomUnits) Find(name string) (DenomUnit, bool) {
+	for _, unit := range u {
+		if unit.Denom == name {
+			return unit, true
+		}
+
+		for _, alias := range unit.Aliases {
+			if alias == name {
+				return unit, true
+			}
+		}
+	}
+
+	return DenomUnit{}, false
+}
+
+// AllUnits returns d's Units, or, if not defined, the units implied by d's Name, DisplayUnit and
+// Precision.
+func (d Denom) AllUnits() DenomUnits {
+	if len(d.Units) > 0 {
+		return d.Units
+	}
+
+	ret := DenomUnits{{Denom: d.Name}}
+	if d.DisplayUnit != "" && d.DisplayUnit != d.Name && d.Precision > 0 {
+		ret = append(ret, DenomUnit{Denom: d.DisplayUnit, Exponent: uint32(d.Precision)})
+	}
+
+	return ret
+}
+
+// Unit returns the unit of d named or aliased name.
+func (d Denom) Unit(name string) (DenomUnit, error) {
+	u, ok := d.AllUnits().Find(name)
+	if !ok {
+		return DenomUnit{}, fmt.Errorf("%w %s for denom %s", ErrUnknownDenomUnit, name, d.Name)
+	}
+
+	return u, nil
+}
+
+// ConvertUnits converts amount, expressed in unit from, into unit to.
+// The conversion is exact, trailing zeros are trimmed; amounts converted to the base unit are
+// rounded to an integer with mode, while amounts in the base unit must be integers.
+func (d Denom) ConvertUnits(amount, from, to string, mode RoundingMode) (string, error) {
+	fromUnit, err := d.Unit(from)
+	if err != nil {
+		return "", err
+	}
+
+	toUnit, err := d.Unit(to)
+	if err != nil {
+		return "", err
+	}
+
+	n, scale, err := parseDecimal(amount)
+	if err != nil {
+		return "", err
+	}
+
+	if fromUnit.Exponent == 0 && scale != 0 {
+		return "", fmt.Errorf("%w: base amount %s must be an integer", ErrInvalidAmount, amount)
+	}
+
+	// amount is n*10^-scale from units, i.e. n*10^(fromExp-toExp-scale) to units: n with
+	// scale+toExp-fromExp fractional digits.
+	scale += int(toUnit.Exponent) - int(fromUnit.Exponent)
+	if scale < 0 {
+		n.Mul(n, pow10(-scale))
+		scale = 0
+	}
+
+	if toUnit.Exponent == 0 && scale > 0 {
+		return roundScaled(n, scale, mode).String(), nil
+	}
+
+	return trimZeros(formatScaled(n, scale)), nil
+}
+
+// ValidateUnits returns an error if d's Units are not consistent with its Name and Precision:
+// the base unit must be Name with exponent 0, unit names and aliases must be unique, and the
+// display unit, DisplayUnit or any unit if empty, must have Precision as exponent.
+// Denoms without Units are valid.
+func (d Denom) ValidateUnits() error {
+	if len(d.Units) == 0 {
+		return nil
+	}
+
+	names := map[string]struct{}{}
+	var (
+		base          *DenomUnit
+		precisionUnit bool
+	)
+
+	for i, u := range d.Units {
+		for _, name := range append([]string{u.Denom}, u.Aliases...) {
+			if name == "" {
+				return fmt.Errorf("denom %s: empty unit name", d.Name)
+			}
+
+			if _, ok := names[name]; ok {
+				return fmt.Errorf("denom %s: duplicate unit %s", d.Name, name)
+			}
+
+			names[name] = struct{}{}
+		}
+
+		if u.Exponent == 0 {
+			if base != nil {
+				return fmt.Errorf("denom %s: multiple base units %s, %s", d.Name, base.Denom, u.Denom)
+			}
+
+			base = &d.Units[i]
+		}
+
+		if int64(u.Exponent) == d.Precision {
+			precisionUnit = true
+		}
+	}
+
+	if base == nil || base.Denom != d.Name {
+		return fmt.Errorf("denom %s: base unit must be %s with exponent 0", d.Name, d.Name)
+	}
+
+	if d.DisplayUnit != "" {
+		u, ok := d.Units.Find(d.DisplayUnit)
+		if !ok {
+			return fmt.Errorf("denom %s: %w %s", d.Name, ErrUnknownDenomUnit, d.DisplayUnit)
+		}
+
+		if int64(u.Exponent) != d.Precision {
+			return fmt.Errorf("denom %s: display unit %s has exponent %d, precision is %d", d.Name, u.Denom, u.Exponent, d.Precision)
+		}
+
+		return nil
+	}
+
+	if !precisionUnit {
+		return fmt.Errorf("denom %s: no unit has exponent %d matching precision", d.Name, d.Precision)
+	}
+
+	return nil
+}
+
+// BankMetadata is the bank module denom Metadata.
+type BankMetadata struct {
+	Description string     `json:"description"`
+	DenomUnits  DenomUnits `json:"denom_units"`
+	Base        string     `json:"base"`
+	Display     string     `json:"display"`
+	Name        string     `json:"name"`
+	Symbol      string     `json:"symbol"`
+}
+
+// ParseDenomsMetadata parses the bank module DenomsMetadata query response, as returned by
+// /cosmos/bank/v1beta1/denoms_metadata.
+func ParseDenomsMetadata(data []byte) ([]BankMetadata, error) {
+	var resp struct {
+		Metadatas []BankMetadata `json:"metadatas"`
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("cannot decode denoms metadata, %w", err)
+	}
+
+	return resp.Metadatas, nil
+}
+
+// WithMetadata returns d with its Units, DisplayUnit and Precision set from m; empty Name, Ticker
+// and DisplayName are filled from m as well.
+// The returned Denom is validated with ValidateUnits.
+func (d Denom) WithMetadata(m BankMetadata) (Denom, error) {
+	if d.Name == "" {
+		d.Name = m.Base
+	}
+
+	if m.Base != d.Name {
+		return Denom{}, fmt.Errorf("metadata base %s doesn't match denom %s", m.Base, d.Name)
+	}
+
+	display, ok := m.DenomUnits.Find(m.Display)
+	if !ok {
+		return Denom{}, fmt.Errorf("denom %s: %w %s", d.Name, ErrUnknownDenomUnit, m.Display)
+	}
+
+	d.Units = m.DenomUnits
+	d.DisplayUnit = display.Denom
+	d.Precision = int64(display.Exponent)
+
+	if d.Ticker == "" {
+		d.Ticker = m.Symbol
+	}
+
+	if d.DisplayName == "" {
+		d.DisplayName = m.Name
+	}
+
+	if err := d.ValidateUnits(); err != nil {
+		return Denom{}, err
+	}
+
+	return d, nil
+}
+
+// ImportMetadata returns l with metadatas applied to the denoms having their base as Name.
+// Metadata of denoms not in l are ignored.
+func (l DenomList) ImportMetadata(metadatas []BankMetadata) (DenomList, error) {
+	ret := make(DenomList, len(l))
+	copy(ret, l)
+
+	for _, m := range metadatas {
+		for i, d := range ret {
+			if d.Name != m.Base {
+				continue
+			}
+
+			updated, err := d.WithMetadata(m)
+			if err != nil {
+				return nil, err
+			}
+
+			ret[i] = updated
+		}
+	}
+
+	return ret, nil
+}
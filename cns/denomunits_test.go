@@ -0,0 +1,133 @@
+package cns_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+)
+
+var atomUnits = cns.Denom{
+	Name:        "uatom",
+	Precision:   6,
+	DisplayUnit: "atom",
+	Units: cns.DenomUnits{
+		{Denom: "uatom", Aliases: []string{"microatom"}},
+		{Denom: "matom", Exponent: 3, Aliases: []string{"milliatom"}},
+		{Denom: "atom", Exponent: 6},
+	},
+}
+
+func TestDenomConvertUnits(t *testing.T) {
+	tests := []struct {
+		name      string
+		denom     cns.Denom
+		amount    string
+		from      string
+		to        string
+		mode      cns.RoundingMode
+		expected  string
+		assertion require.ErrorAssertionFunc
+	}{
+		{"base to display", atomUnits, "1234567", "uatom", "atom", cns.RoundDown, "1.234567", require.NoError},
+		{"base to intermediate unit", atomUnits, "1234567", "uatom", "matom", cns.RoundDown, "1234.567", require.NoError},
+		{"display to intermediate unit", atomUnits, "1.5", "atom", "matom", cns.RoundDown, "1500", require.NoError},
+		{"intermediate unit to display", atomUnits, "1500", "matom", "atom", cns.RoundDown, "1.5", require.NoError},
+		{"display to base", atomUnits, "0.0000015", "atom", "uatom", cns.RoundHalfUp, "2", require.NoError},
+		{"display to base, rounded down", atomUnits, "0.0000015", "atom", "uatom", cns.RoundDown, "1", require.NoError},
+		{"aliases", atomUnits, "2", "milliatom", "microatom", cns.RoundDown, "2000", require.NoError},
+		{"same unit", atomUnits, "1.50", "atom", "atom", cns.RoundDown, "1.5", require.NoError},
+		{"implied units", cns.Denom{Name: "uosmo", Precision: 6, DisplayUnit: "osmo"}, "2500000", "uosmo", "osmo", cns.RoundDown, "2.5", require.NoError},
+		{"unknown unit", atomUnits, "1", "uatom", "katom", cns.RoundDown, "", require.Error},
+		{"invalid amount", atomUnits, "1e6", "uatom", "atom", cns.RoundDown, "", require.Error},
+		{"fractional base amount", atomUnits, "1.5", "uatom", "uatom", cns.RoundDown, "", require.Error},
+		{"fractional base amount to display", atomUnits, "1.5", "microatom", "atom", cns.RoundDown, "", require.Error},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := tt.denom.ConvertUnits(tt.amount, tt.from, tt.to, tt.mode)
+			tt.assertion(t, err)
+			require.Equal(t, tt.expected, res)
+		})
+	}
+
+	_, err := atomUnits.ConvertUnits("1", "katom", "atom", cns.RoundDown)
+	require.ErrorIs(t, err, cns.ErrUnknownDenomUnit)
+
+	_, err = atomUnits.ConvertUnits("1.5", "uatom", "uatom", cns.RoundDown)
+	require.ErrorIs(t, err, cns.ErrInvalidAmount)
+}
+
+func TestDenomValidateUnits(t *testing.T) {
+	tests := []struct {
+		name      string
+		edit      func(*cns.Denom)
+		assertion require.ErrorAssertionFunc
+	}{
+		{"valid", func(*cns.Denom) {}, require.NoError},
+		{"no units", func(d *cns.Denom) { d.Units = nil }, require.NoError},
+		{"non-default display unit", func(d *cns.Denom) { d.DisplayUnit, d.Precision = "matom", 3 }, require.NoError},
+		{"no display unit", func(d *cns.Denom) { d.DisplayUnit = "" }, require.NoError},
+		{"display unit exponent mismatch", func(d *cns.Denom) { d.Precision = 3 }, require.Error},
+		{"no unit matching precision", func(d *cns.Denom) { d.DisplayUnit, d.Precision = "", 18 }, require.Error},
+		{"unknown display unit", func(d *cns.Denom) { d.DisplayUnit = "katom" }, require.Error},
+		{"base unit not name", func(d *cns.Denom) { d.Name = "atom" }, require.Error},
+		{"duplicate alias", func(d *cns.Denom) { d.Units[2].Aliases = []string{"microatom"} }, require.Error},
+		{"multiple base units", func(d *cns.Denom) { d.Units[1].Exponent = 0 }, require.Error},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			d := atomUnits
+			d.Units = append(cns.DenomUnits{}, atomUnits.Units...)
+			tt.edit(&d)
+			tt.assertion(t, d.ValidateUnits())
+		})
+	}
+}
+
+func TestImportDenomsMetadata(t *testing.T) {
+	data, err := os.ReadFile("testdata/denoms_metadata.json")
+	require.NoError(t, err)
+
+	metadatas, err := cns.ParseDenomsMetadata(data)
+	require.NoError(t, err)
+	require.Len(t, metadatas, 2)
+
+	list := cns.DenomList{
+		{Name: "uatom", Ticker: "ATOM", Precision: 6, FeeToken: true},
+		{Name: "ufoo"},
+		{Name: "ubar", Precision: 6},
+	}
+
+	res, err := list.ImportMetadata(metadatas)
+	require.NoError(t, err)
+	require.Len(t, res, 3)
+
+	require.Equal(t, "atom", res[0].DisplayUnit)
+	require.Equal(t, "Cosmos Hub Atom", res[0].DisplayName)
+	require.True(t, res[0].FeeToken)
+	require.Len(t, res[0].Units, 3)
+
+	require.Equal(t, "mfoo", res[1].DisplayUnit)
+	require.Equal(t, int64(3), res[1].Precision)
+	require.Equal(t, "FOO", res[1].Ticker)
+
+	display, err := res[1].ToDisplay("1500")
+	require.NoError(t, err)
+	require.Equal(t, "1.5", display)
+
+	require.Equal(t, list[2], res[2])
+	require.Empty(t, list[1].Units, "the original list must not be modified")
+
+	_, err = cns.Denom{Name: "uosmo"}.WithMetadata(metadatas[0])
+	require.Error(t, err)
+
+	_, err = cns.ParseDenomsMetadata([]byte("{"))
+	require.Error(t, err)
+}
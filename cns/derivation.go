@@ -0,0 +1,260 @@
+package cns
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/ripemd160" //nolint:staticcheck // RIPEMD-160 is part of the Cosmos SDK address format
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	hardenedOffset = 0x80000000
+
+	// bip44Purpose is the purpose level of BIP-44 derivation paths.
+	bip44Purpose = 44
+)
+
+// ErrInvalidHDPath is returned when a derivation path is not a valid BIP-44 path.
+var ErrInvalidHDPath = errors.New("invalid BIP-44 derivation path")
+
+// CoinType returns the SLIP-44 coin type defined in c's DerivationPath, e.g. 118 for m/44'/118'/0'/0/0.
+func (c Chain) CoinType() (uint32, error) {
+	if !strings.HasPrefix(c.DerivationPath, "m/") {
+		return 0, fmt.Errorf("chain %s derivation path %q is not absolute", c.ChainName, c.DerivationPath)
+	}
+
+	path, err := accounts.ParseDerivationPath(c.DerivationPath)
+	if err != nil {
+		return 0, fmt.Errorf("chain %s has invalid derivation path, %w", c.ChainName, err)
+	}
+
+	if len(path) < 2 {
+		return 0, fmt.Errorf("chain %s derivation path %q has no coin type", c.ChainName, c.DerivationPath)
+	}
+
+	return path[1] &^ hardenedOffset, nil
+}
+
+// SameCoinType returns true if c and other derive keys with the same coin type, i.e. the same
+// mnemonic yields the same account key on both chains.
+func (c Chain) SameCoinType(other Chain) (bool, error) {
+	ct, err := c.CoinType()
+	if err != nil {
+		return false, err
+	}
+
+	otherCt, err := other.CoinType()
+	if err != nil {
+		return false, err
+	}
+
+	return ct == otherCt, nil
+}
+
+// HDPath is a BIP-44 derivation path, m/purpose'/coin_type'/account'/change/address_index.
+// Hardening is implied by the level, values don't include the hardened offset.
+type HDPath struct {
+	Purpose  uint32 `json:"purpose"`
+	CoinType uint32 `json:"coin_type"`
+	Account  uint32 `json:"account"`
+	Change   uint32 `json:"change"`
+	Index    uint32 `json:"index"`
+}
+
+// ParseHDPath parses an absolute, five levels BIP-44 path such as m/44'/118'/0'/0/0.
+// Purpose, coin type and account must be hardened, change and index must not.
+func ParseHDPath(s string) (HDPath, error) {
+	if !strings.HasPrefix(s, "m/") {
+		return HDPath{}, fmt.Errorf("%w: %q is not absolute", ErrInvalidHDPath, s)
+	}
+
+	path, err := accounts.ParseDerivationPath(s)
+	if err != nil {
+		return HDPath{}, fmt.Errorf("%w: %s", ErrInvalidHDPath, err)
+	}
+
+	if len(path) != 5 {
+		return HDPath{}, fmt.Errorf("%w: %q has %d levels, expected 5", ErrInvalidHDPath, s, len(path))
+	}
+
+	for i, c := range path {
+		if hardened := c >= hardenedOffset; hardened != (i < 3) {
+			return HDPath{}, fmt.Errorf("%w: %q level %d has wrong hardening", ErrInvalidHDPath, s, i)
+		}
+	}
+
+	if path[0] != bip44Purpose+hardenedOffset {
+		return HDPath{}, fmt.Errorf("%w: %q purpose is not %d", ErrInvalidHDPath, s, bip44Purpose)
+	}
+
+	return HDPath{
+		Purpose:  path[0] &^ hardenedOffset,
+		CoinType: path[1] &^ hardenedOffset,
+		Account:  path[2] &^ hardenedOffset,
+		Change:   path[3],
+		Index:    path[4],
+	}, nil
+}
+
+// String implements the fmt.Stringer interface.
+func (p HDPath) String() string {
+	return fmt.Sprintf("m/%d'/%d'/%d'/%d/%d", p.Purpose, p.CoinType, p.Account, p.Change, p.Index)
+}
+
+func (p HDPath) components() []uint32 {
+	return []uint32{
+		p.Purpose + hardenedOffset,
+		p.CoinType + hardenedOffset,
+		p.Account + hardenedOffset,
+		p.Change,
+		p.Index,
+	}
+}
+
+// HDPath returns c's DerivationPath as an HDPath.
+func (c Chain) HDPath() (HDPath, error) {
+	p, err := ParseHDPath(c.DerivationPath)
+	if err != nil {
+		return HDPath{}, fmt.Errorf("chain %s: %w", c.ChainName, err)
+	}
+
+	return p, nil
+}
+
+// DerivedAccount is an account derived from a mnemonic.
+type DerivedAccount struct {
+	Path    HDPath `json:"path"`
+	PubKey  []byte `json:"pub_key"` // compressed secp256k1 public key
+	Address string `json:"address"`
+}
+
+// DeriveAccount derives the account at c's DerivationPath from mnemonic and passphrase, and
+// encodes its address with c's Bech32Config.
+// Chains with coin type 60 use Ethereum-style addresses (eth_secp256k1), others use the
+// Cosmos SDK secp256k1 address format.
+// Meant for test fixtures and configuration checks: the mnemonic checksum is not verified,
+// and private keys are never returned.
+func (c Chain) DeriveAccount(mnemonic, passphrase string) (DerivedAccount, error) {
+	path, err := c.HDPath()
+	if err != nil {
+		return DerivedAccount{}, err
+	}
+
+	key, err := deriveKey(mnemonic, passphrase, path)
+	if err != nil {
+		return DerivedAccount{}, fmt.Errorf("chain %s: %w", c.ChainName, err)
+	}
+
+	priv, err := crypto.ToECDSA(key)
+	if err != nil {
+		return DerivedAccount{}, fmt.Errorf("chain %s: %w", c.ChainName, err)
+	}
+
+	pubKey := crypto.CompressPubkey(&priv.PublicKey)
+
+	var addr []byte
+	if path.CoinType == ethCoinType {
+		addr = crypto.PubkeyToAddress(priv.PublicKey).Bytes()
+	} else {
+		addr = cosmosAddress(pubKey)
+	}
+
+	address, err := c.NodeInfo.Bech32Config.EncodeAddress(addr, RoleAccount)
+	if err != nil {
+		return DerivedAccount{}, fmt.Errorf("chain %s: %w", c.ChainName, err)
+	}
+
+	return DerivedAccount{
+		Path:    path,
+		PubKey:  pubKey,
+		Address: address,
+	}, nil
+}
+
+// deriveKey returns the BIP-32 secp256k1 private key at path for the BIP-39 seed of mnemonic and passphrase.
+func deriveKey(mnemonic, passphrase string, path HDPath) ([]byte, error) {
+	mnemonic = norm.NFKD.String(strings.Join(strings.Fields(mnemonic), " "))
+	if mnemonic == "" {
+		return nil, errors.New("empty mnemonic")
+	}
+
+	seed := pbkdf2.Key([]byte(mnemonic), []byte(norm.NFKD.String("mnemonic"+passphrase)), 2048, 64, sha512.New)
+
+	master := hmacSHA512([]byte("Bitcoin seed"), seed)
+	key, chainCode := master[:32], master[32:]
+
+	n := crypto.S256().Params().N
+	if k := new(big.Int).SetBytes(key); k.Sign() == 0 || k.Cmp(n) >= 0 {
+		return nil, errors.New("invalid master key")
+	}
+
+	for _, index := range path.components() {
+		var err error
+		key, chainCode, err = deriveChildKey(key, chainCode, index)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return key, nil
+}
+
+// deriveChildKey implements the BIP-32 private parent key to private child key derivation.
+func deriveChildKey(key, chainCode []byte, index uint32) ([]byte, []byte, error) {
+	var data []byte
+	if index >= hardenedOffset {
+		data = append([]byte{0}, key...)
+	} else {
+		priv, err := crypto.ToECDSA(key)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		data = crypto.CompressPubkey(&priv.PublicKey)
+	}
+
+	var ser [4]byte
+	binary.BigEndian.PutUint32(ser[:], index)
+	data = append(data, ser[:]...)
+
+	i := hmacSHA512(chainCode, data)
+
+	n := crypto.S256().Params().N
+	il := new(big.Int).SetBytes(i[:32])
+	if il.Cmp(n) >= 0 {
+		return nil, nil, fmt.Errorf("invalid child key at index %d", index)
+	}
+
+	child := il.Add(il, new(big.Int).SetBytes(key))
+	child.Mod(child, n)
+	if child.Sign() == 0 {
+		return nil, nil, fmt.Errorf("invalid child key at index %d", index)
+	}
+
+	return child.FillBytes(make([]byte, 32)), i[32:], nil
+}
+
+func hmacSHA512(key, data []byte) []byte {
+	h := hmac.New(sha512.New, key)
+	_, _ = h.Write(data)
+	return h.Sum(nil)
+}
+
+// cosmosAddress returns the Cosmos SDK address of a compressed secp256k1 public key,
+// RIPEMD160(SHA256(pubkey)).
+func cosmosAddress(pubKey []byte) []byte {
+	sha := sha256.Sum256(pubKey)
+	h := ripemd160.New()
+	_, _ = h.Write(sha[:])
+	return h.Sum(nil)
+}
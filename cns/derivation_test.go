@@ -0,0 +1,100 @@
+package cns_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+)
+
+// testMnemonic is the well-known all-zero entropy BIP-39 mnemonic, never use it for real funds.
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestParseHDPath(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		expected  cns.HDPath
+		assertion require.ErrorAssertionFunc
+	}{
+		{"cosmos", "m/44'/118'/0'/0/0", cns.HDPath{Purpose: 44, CoinType: 118}, require.NoError},
+		{"non-zero levels", "m/44'/60'/2'/1/7", cns.HDPath{Purpose: 44, CoinType: 60, Account: 2, Change: 1, Index: 7}, require.NoError},
+		{"relative path", "44'/118'/0'/0/0", cns.HDPath{}, require.Error},
+		{"too short", "m/44'/118'/0'", cns.HDPath{}, require.Error},
+		{"too long", "m/44'/118'/0'/0/0/0", cns.HDPath{}, require.Error},
+		{"unhardened coin type", "m/44'/118/0'/0/0", cns.HDPath{}, require.Error},
+		{"hardened index", "m/44'/118'/0'/0/0'", cns.HDPath{}, require.Error},
+		{"wrong purpose", "m/49'/118'/0'/0/0", cns.HDPath{}, require.Error},
+		{"not a path", "m/foo", cns.HDPath{}, require.Error},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := cns.ParseHDPath(tt.path)
+			tt.assertion(t, err)
+			require.Equal(t, tt.expected, p)
+			if err == nil {
+				require.Equal(t, tt.path, p.String())
+			} else {
+				require.ErrorIs(t, err, cns.ErrInvalidHDPath)
+			}
+		})
+	}
+}
+
+func TestChainSameCoinType(t *testing.T) {
+	same, err := cosmosHub.SameCoinType(osmosis)
+	require.NoError(t, err)
+	require.True(t, same)
+
+	same, err = cosmosHub.SameCoinType(cryptoOrg)
+	require.NoError(t, err)
+	require.False(t, same)
+
+	_, err = cosmosHub.SameCoinType(testChain("foo", "foo", "m/foo"))
+	require.Error(t, err)
+}
+
+func TestChainDeriveAccount(t *testing.T) {
+	acc, err := cosmosHub.DeriveAccount(testMnemonic, "")
+	require.NoError(t, err)
+	require.Equal(t, "cosmos19rl4cm2hmr8afy4kldpxz3fka4jguq0auqdal4", acc.Address)
+	require.Equal(t, "m/44'/118'/0'/0/0", acc.Path.String())
+	require.Len(t, acc.PubKey, 33)
+
+	// chains sharing a coin type derive the same key.
+	osmoAcc, err := osmosis.DeriveAccount(testMnemonic, "")
+	require.NoError(t, err)
+	require.Equal(t, acc.PubKey, osmoAcc.PubKey)
+
+	converted, err := cns.ConvertAddress(acc.Address, cosmosHub, osmosis)
+	require.NoError(t, err)
+	require.Equal(t, converted, osmoAcc.Address)
+
+	// extra whitespace is normalized away, while a passphrase yields another key.
+	same, err := cosmosHub.DeriveAccount("  "+testMnemonic+"\n", "")
+	require.NoError(t, err)
+	require.Equal(t, acc, same)
+
+	other, err := cosmosHub.DeriveAccount(testMnemonic, "passphrase")
+	require.NoError(t, err)
+	require.NotEqual(t, acc.Address, other.Address)
+
+	// coin type 60 chains use Ethereum addresses.
+	evmos := testChain("evmos", "evmos", "m/44'/60'/0'/0/0")
+	evmosAcc, err := evmos.DeriveAccount(testMnemonic, "")
+	require.NoError(t, err)
+
+	bz, err := evmos.NodeInfo.Bech32Config.DecodeAddress(evmosAcc.Address, cns.RoleAccount)
+	require.NoError(t, err)
+	require.Equal(t, "9858effd232b4033e47d90003d41ec34ecaeda94", hex.EncodeToString(bz))
+
+	_, err = testChain("foo", "foo", "m/44'/118'/0'").DeriveAccount(testMnemonic, "")
+	require.ErrorIs(t, err, cns.ErrInvalidHDPath)
+
+	_, err = cosmosHub.DeriveAccount(" ", "")
+	require.Error(t, err)
+}
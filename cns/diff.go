@@ -0,0 +1,337 @@
+package cns
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	auditTag           = "audit"
+	auditTagIgnore     = "-"
+	auditTagIdentifier = "identifier"
+)
+
+// ChangeType represents the kind of a Change.
+type ChangeType string
+
+const (
+	// ChangeAdded marks a value that is present only in the new Chain.
+	ChangeAdded ChangeType = "added"
+	// ChangeRemoved marks a value that is present only in the old Chain.
+	ChangeRemoved ChangeType = "removed"
+	// ChangeUpdated marks a value that is present in both Chains with different content.
+	ChangeUpdated ChangeType = "updated"
+)
+
+// Change represents a single field-level difference between two values.
+type Change struct {
+	Type ChangeType  `json:"type"`
+	Path []string    `json:"path"`
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// PathString returns the dot-separated representation of c's Path.
+func (c Change) PathString() string {
+	return strings.Join(c.Path, ".")
+}
+
+// Changelog is a list of Change.
+type Changelog []Change
+
+// Paths returns the dot-separated paths of all the changes in cl.
+func (cl Changelog) Paths() []string {
+	ret := make([]string, 0, len(cl))
+	for _, c := range cl {
+		ret = append(ret, c.PathString())
+	}
+
+	return ret
+}
+
+// Diff returns the list of field-level changes needed to go from old to new.
+//
+// Struct fields are walked recursively, and honor the `audit` struct tag:
+//   - `audit:"-"` excludes the field from the comparison;
+//   - `audit:"name"` overrides the path element used for the field;
+//   - `audit:"name,identifier"` marks the field as the key used to match
+//     elements of a slice of structs, e.g. Denom.Name for DenomList.
+//
+// Fields without an `audit` tag use their JSON name as path element.
+// The `diff` tags used by r3labs/diff consumers are not taken into account.
+// Maps are compared key by key, slices of structs with an identifier are
+// compared element by element, and slices of scalars are compared as sets.
+func Diff(old, new Chain) Changelog {
+	var cl Changelog
+	diffValues(&cl, nil, reflect.ValueOf(old), reflect.ValueOf(new))
+	return cl
+}
+
+func diffValues(cl *Changelog, path []string, a, b reflect.Value) {
+	if a.Kind() == reflect.Ptr {
+		switch {
+		case a.IsNil() && b.IsNil():
+			return
+		case a.IsNil():
+			cl.add(ChangeAdded, path, nil, b.Elem().Interface())
+			return
+		case b.IsNil():
+			cl.add(ChangeRemoved, path, a.Elem().Interface(), nil)
+			return
+		}
+
+		a, b = a.Elem(), b.Elem()
+	}
+
+	switch a.Kind() {
+	case reflect.Struct:
+		diffStructs(cl, path, a, b)
+	case reflect.Map:
+		diffMaps(cl, path, a, b)
+	case reflect.Slice, reflect.Array:
+		diffSlices(cl, path, a, b)
+	default:
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			cl.add(ChangeUpdated, path, a.Interface(), b.Interface())
+		}
+	}
+}
+
+func diffStructs(cl *Changelog, path []string, a, b reflect.Value) {
+	t := a.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name, _, ignore := diffFieldName(f)
+		if ignore {
+			continue
+		}
+
+		diffValues(cl, appendPath(path, name), a.Field(i), b.Field(i))
+	}
+}
+
+func diffMaps(cl *Changelog, path []string, a, b reflect.Value) {
+	for _, k := range sortedMapKeys(a, b) {
+		av, bv := a.MapIndex(k), b.MapIndex(k)
+		p := appendPath(path, fmt.Sprint(k.Interface()))
+
+		switch {
+		case !av.IsValid():
+			cl.add(ChangeAdded, p, nil, bv.Interface())
+		case !bv.IsValid():
+			cl.add(ChangeRemoved, p, av.Interface(), nil)
+		default:
+			diffValues(cl, p, av, bv)
+		}
+	}
+}
+
+func diffSlices(cl *Changelog, path []string, a, b reflect.Value) {
+	elem := a.Type().Elem()
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+
+	if elem.Kind() == reflect.Struct {
+		if idx, ok := identifierField(elem); ok {
+			diffIdentifiedSlices(cl, path, a, b, idx)
+			return
+		}
+
+		diffIndexedSlices(cl, path, a, b)
+		return
+	}
+
+	diffScalarSlices(cl, path, a, b)
+}
+
+// diffIdentifiedSlices matches slice elements by the value of their identifier field.
+func diffIdentifiedSlices(cl *Changelog, path []string, a, b reflect.Value, idx int) {
+	am, bm := identifiedElements(a, idx), identifiedElements(b, idx)
+
+	for _, k := range sortedKeys(am, bm) {
+		av, aok := am[k]
+		bv, bok := bm[k]
+		p := appendPath(path, k)
+
+		switch {
+		case !aok:
+			cl.add(ChangeAdded, p, nil, bv.Interface())
+		case !bok:
+			cl.add(ChangeRemoved, p, av.Interface(), nil)
+		default:
+			diffValues(cl, p, av, bv)
+		}
+	}
+}
+
+// diffIndexedSlices matches slice elements by their position.
+func diffIndexedSlices(cl *Changelog, path []string, a, b reflect.Value) {
+	n := a.Len()
+	if b.Len() > n {
+		n = b.Len()
+	}
+
+	for i := 0; i < n; i++ {
+		p := appendPath(path, fmt.Sprint(i))
+
+		switch {
+		case i >= a.Len():
+			cl.add(ChangeAdded, p, nil, b.Index(i).Interface())
+		case i >= b.Len():
+			cl.add(ChangeRemoved, p, a.Index(i).Interface(), nil)
+		default:
+			diffValues(cl, p, a.Index(i), b.Index(i))
+		}
+	}
+}
+
+// diffScalarSlices compares slices of scalars as sets, ignoring ordering.
+func diffScalarSlices(cl *Changelog, path []string, a, b reflect.Value) {
+	as, bs := scalarSet(a), scalarSet(b)
+
+	for _, k := range sortedKeys(as, bs) {
+		av, aok := as[k]
+		bv, bok := bs[k]
+
+		switch {
+		case !aok:
+			cl.add(ChangeAdded, appendPath(path, k), nil, bv.Interface())
+		case !bok:
+			cl.add(ChangeRemoved, appendPath(path, k), av.Interface(), nil)
+		}
+	}
+}
+
+func (cl *Changelog) add(t ChangeType, path []string, from, to interface{}) {
+	*cl = append(*cl, Change{
+		Type: t,
+		Path: path,
+		From: from,
+		To:   to,
+	})
+}
+
+// diffFieldName returns the path element for f, whether f is the identifier of its struct
+// and whether f must be ignored.
+func diffFieldName(f reflect.StructField) (string, bool, bool) {
+	tag, ok := f.Tag.Lookup(auditTag)
+	if ok {
+		if tag == auditTagIgnore {
+			return "", false, true
+		}
+
+		parts := strings.Split(tag, ",")
+		name := parts[0]
+		if name == "" {
+			name = fieldName(f)
+		}
+
+		identifier := false
+		for _, opt := range parts[1:] {
+			if opt == auditTagIdentifier {
+				identifier = true
+			}
+		}
+
+		return name, identifier, false
+	}
+
+	return fieldName(f), false, false
+}
+
+// fieldName returns the JSON name of f, or its Go name if not available.
+func fieldName(f reflect.StructField) string {
+	name := strings.SplitN(f.Tag.Get("json"), ",", 2)[0]
+	if name == "" || name == "-" {
+		return f.Name
+	}
+
+	return name
+}
+
+func identifierField(t reflect.Type) (int, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		if _, identifier, ignore := diffFieldName(t.Field(i)); identifier && !ignore {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+func identifiedElements(s reflect.Value, idx int) map[string]reflect.Value {
+	ret := make(map[string]reflect.Value, s.Len())
+	for i := 0; i < s.Len(); i++ {
+		e := s.Index(i)
+		se := e
+		if se.Kind() == reflect.Ptr {
+			if se.IsNil() {
+				continue
+			}
+			se = se.Elem()
+		}
+
+		ret[fmt.Sprint(se.Field(idx).Interface())] = e
+	}
+
+	return ret
+}
+
+func scalarSet(s reflect.Value) map[string]reflect.Value {
+	ret := make(map[string]reflect.Value, s.Len())
+	for i := 0; i < s.Len(); i++ {
+		ret[fmt.Sprint(s.Index(i).Interface())] = s.Index(i)
+	}
+
+	return ret
+}
+
+func sortedKeys(a, b map[string]reflect.Value) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedMapKeys(a, b reflect.Value) []reflect.Value {
+	seen := map[interface{}]bool{}
+	var keys []reflect.Value
+	for _, m := range []reflect.Value{a, b} {
+		for _, k := range m.MapKeys() {
+			if seen[k.Interface()] {
+				continue
+			}
+
+			seen[k.Interface()] = true
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+
+	return keys
+}
+
+func appendPath(path []string, elem string) []string {
+	ret := make([]string, len(path), len(path)+1)
+	copy(ret, path)
+	return append(ret, elem)
+}
@@ -0,0 +1,156 @@
+package cns_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+)
+
+func diffTestChain() cns.Chain {
+	thresh := int64(1000)
+
+	return cns.Chain{
+		ID:             1,
+		Enabled:        true,
+		ChainName:      "cosmos-hub",
+		DisplayName:    "Cosmos Hub",
+		PrimaryChannel: cns.DbStringMap{"osmosis": "channel-141"},
+		Denoms: cns.DenomList{
+			{
+				Name:                        "uatom",
+				Precision:                   6,
+				RelayerDenom:                true,
+				MinimumThreshRelayerBalance: &thresh,
+			},
+		},
+		DemerisAddresses: []string{"cosmos1feeaddr"},
+		NodeInfo: cns.NodeInfo{
+			Endpoint: "http://localhost:26657",
+			ChainID:  "cosmoshub-4",
+			Bech32Config: cns.Bech32Config{
+				MainPrefix: "cosmos",
+			},
+		},
+		PublicNodeEndpoints: cns.PublicNodeEndpoints{
+			TendermintRPC: []string{"https://rpc.cosmos.network:443"},
+		},
+		CosmosSDKVersion: "v0.45.1",
+	}
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		mutate   func(c *cns.Chain)
+		expected cns.Changelog
+	}{
+		{
+			"equal chains have no changes",
+			func(c *cns.Chain) {},
+			nil,
+		},
+		{
+			"fields tagged with audit:\"-\" are ignored",
+			func(c *cns.Chain) { c.ID = 42 },
+			nil,
+		},
+		{
+			"top-level field updated",
+			func(c *cns.Chain) { c.CosmosSDKVersion = "v0.46.0" },
+			cns.Changelog{
+				{Type: cns.ChangeUpdated, Path: []string{"cosmos_sdk_version"}, From: "v0.45.1", To: "v0.46.0"},
+			},
+		},
+		{
+			"nested node info and bech32 config",
+			func(c *cns.Chain) {
+				c.NodeInfo.ChainID = "cosmoshub-5"
+				c.NodeInfo.Bech32Config.MainPrefix = "atom"
+			},
+			cns.Changelog{
+				{Type: cns.ChangeUpdated, Path: []string{"node_info", "chain_id"}, From: "cosmoshub-4", To: "cosmoshub-5"},
+				{Type: cns.ChangeUpdated, Path: []string{"node_info", "bech32_config", "main_prefix"}, From: "cosmos", To: "atom"},
+			},
+		},
+		{
+			"denoms keyed by name",
+			func(c *cns.Chain) {
+				c.Denoms = cns.DenomList{
+					{Name: "stake"},
+					{Name: "uatom", Precision: 8, RelayerDenom: true},
+				}
+			},
+			cns.Changelog{
+				{Type: cns.ChangeAdded, Path: []string{"denoms", "stake"}, To: cns.Denom{Name: "stake"}},
+				{Type: cns.ChangeUpdated, Path: []string{"denoms", "uatom", "precision"}, From: int64(6), To: int64(8)},
+				{Type: cns.ChangeRemoved, Path: []string{"denoms", "uatom", "minimum_thresh_relayer_balance"}, From: int64(1000)},
+			},
+		},
+		{
+			"primary channel keys",
+			func(c *cns.Chain) {
+				c.PrimaryChannel = cns.DbStringMap{"akash": "channel-184"}
+			},
+			cns.Changelog{
+				{Type: cns.ChangeAdded, Path: []string{"primary_channel", "akash"}, To: "channel-184"},
+				{Type: cns.ChangeRemoved, Path: []string{"primary_channel", "osmosis"}, From: "channel-141"},
+			},
+		},
+		{
+			"slices of strings are compared as sets",
+			func(c *cns.Chain) {
+				c.DemerisAddresses = []string{"cosmos1feeaddr"}
+				c.PublicNodeEndpoints.TendermintRPC = []string{"https://rpc2.cosmos.network:443", "https://rpc.cosmos.network:443"}
+			},
+			cns.Changelog{
+				{Type: cns.ChangeAdded, Path: []string{"public_node_endpoints", "tendermint_rpc", "https://rpc2.cosmos.network:443"}, To: "https://rpc2.cosmos.network:443"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			old := diffTestChain()
+			new := diffTestChain()
+			tt.mutate(&new)
+
+			require.Equal(t, tt.expected, cns.Diff(old, new))
+		})
+	}
+}
+
+func TestChangelogPaths(t *testing.T) {
+	old := diffTestChain()
+	new := diffTestChain()
+	new.Enabled = false
+	new.NodeInfo.Endpoint = "http://localhost:26658"
+
+	require.Equal(t, []string{"enabled", "node_info.endpoint"}, cns.Diff(old, new).Paths())
+}
+
+func TestDiffIgnoresR3labsTags(t *testing.T) {
+	// Chain fields keep their r3labs/diff tags for existing consumers, only ChainName is diffed
+	// by them, while Diff audits every field but ID.
+	typ := reflect.TypeOf(cns.Chain{})
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		tag, ok := f.Tag.Lookup("diff")
+		if f.Name == "ChainName" {
+			require.False(t, ok, f.Name)
+			continue
+		}
+
+		require.Equal(t, "-", tag, f.Name)
+	}
+
+	old := diffTestChain()
+	new := diffTestChain()
+	new.DisplayName = "Cosmos"
+	new.DemerisAddresses = []string{"cosmos1newfeeaddr"}
+
+	require.Equal(t, []string{"display_name", "demeris_addresses.cosmos1feeaddr", "demeris_addresses.cosmos1newfeeaddr"}, cns.Diff(old, new).Paths())
+}
@@ -0,0 +1,201 @@
+// Package endpoints probes chains' public node endpoints and selects healthy ones.
+package endpoints
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+)
+
+const (
+	// StatusPath is the Tendermint RPC path queried to probe TendermintRPC endpoints.
+	StatusPath = "/status"
+	// NodeInfoPath is the Cosmos API path queried to probe CosmosAPI endpoints.
+	NodeInfoPath = "/cosmos/base/tendermint/v1beta1/node_info"
+
+	maxResponseSize = 1 << 20
+)
+
+// ErrChainIDMismatch is returned when an endpoint reports a chain ID different from the expected one.
+var ErrChainIDMismatch = errors.New("chain id mismatch")
+
+// Kind identifies the API exposed by an endpoint.
+type Kind string
+
+const (
+	// KindTendermintRPC is a Tendermint RPC endpoint.
+	KindTendermintRPC Kind = "tendermint_rpc"
+	// KindCosmosAPI is a Cosmos SDK REST API endpoint.
+	KindCosmosAPI Kind = "cosmos_api"
+)
+
+// Result is the outcome of an endpoint probe.
+type Result struct {
+	URL       string        `json:"url"`
+	Kind      Kind          `json:"kind"`
+	Healthy   bool          `json:"healthy"`
+	ChainID   string        `json:"chain_id,omitempty"` // chain ID reported by the endpoint
+	Latency   time.Duration `json:"latency"`
+	Error     string        `json:"error,omitempty"`
+	CheckedAt time.Time     `json:"checked_at"`
+}
+
+// Prober probes endpoints over HTTP.
+type Prober struct {
+	Client *http.Client // http.DefaultClient if nil
+}
+
+// NewProber returns a Prober using client, or http.DefaultClient if nil.
+func NewProber(client *http.Client) Prober {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return Prober{Client: client}
+}
+
+// Probe queries the endpoint at url, exposing the kind API, and checks it serves chainID.
+func (p Prober) Probe(ctx context.Context, kind Kind, url, chainID string) Result {
+	res := Result{URL: url, Kind: kind, CheckedAt: time.Now()}
+
+	reported, err := p.chainID(ctx, kind, url)
+	res.Latency = time.Since(res.CheckedAt)
+	res.ChainID = reported
+
+	if err == nil && reported != chainID {
+		err = fmt.Errorf("%w: endpoint reports %s, expected %s", ErrChainIDMismatch, reported, chainID)
+	}
+
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+
+	res.Healthy = true
+
+	return res
+}
+
+// ProbeChain concurrently probes all of c's public node endpoints against its NodeInfo.ChainID.
+// Results are returned TendermintRPC endpoints first, in the order they're defined in c.
+func (p Prober) ProbeChain(ctx context.Context, c cns.Chain) []Result {
+	type target struct {
+		kind Kind
+		url  string
+	}
+
+	var targets []target
+	for _, u := range c.PublicNodeEndpoints.TendermintRPC {
+		targets = append(targets, target{KindTendermintRPC, u})
+	}
+
+	for _, u := range c.PublicNodeEndpoints.CosmosAPI {
+		targets = append(targets, target{KindCosmosAPI, u})
+	}
+
+	ret := make([]Result, len(targets))
+
+	var wg sync.WaitGroup
+	for i, t := range targets {
+		wg.Add(1)
+		go func(i int, t target) {
+			defer wg.Done()
+			ret[i] = p.Probe(ctx, t.kind, t.url, c.NodeInfo.ChainID)
+		}(i, t)
+	}
+
+	wg.Wait()
+
+	return ret
+}
+
+// tendermintStatus is the subset of the Tendermint /status response we need.
+// Tendermint wraps it in a JSON-RPC envelope, some proxies don't.
+type tendermintStatus struct {
+	Result *struct {
+		NodeInfo struct {
+			Network string `json:"network"`
+		} `json:"node_info"`
+	} `json:"result"`
+	NodeInfo *struct {
+		Network string `json:"network"`
+	} `json:"node_info"`
+}
+
+// cosmosNodeInfo is the subset of the Cosmos API node_info response we need.
+type cosmosNodeInfo struct {
+	DefaultNodeInfo *struct {
+		Network string `json:"network"`
+	} `json:"default_node_info"`
+}
+
+func (p Prober) chainID(ctx context.Context, kind Kind, url string) (string, error) {
+	switch kind {
+	case KindTendermintRPC:
+		var s tendermintStatus
+		if err := p.get(ctx, url, StatusPath, &s); err != nil {
+			return "", err
+		}
+
+		switch {
+		case s.Result != nil:
+			return s.Result.NodeInfo.Network, nil
+		case s.NodeInfo != nil:
+			return s.NodeInfo.Network, nil
+		default:
+			return "", errors.New("status response has no node info")
+		}
+	case KindCosmosAPI:
+		var n cosmosNodeInfo
+		if err := p.get(ctx, url, NodeInfoPath, &n); err != nil {
+			return "", err
+		}
+
+		if n.DefaultNodeInfo == nil {
+			return "", errors.New("node_info response has no default node info")
+		}
+
+		return n.DefaultNodeInfo.Network, nil
+	default:
+		return "", fmt.Errorf("unknown endpoint kind %s", kind)
+	}
+}
+
+func (p Prober) get(ctx context.Context, url, path string, dst interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(url, "/")+path, nil)
+	if err != nil {
+		return err
+	}
+
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned status %d", path, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(dst); err != nil {
+		return fmt.Errorf("cannot decode %s response, %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package endpoints_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+	"github.com/emerishq/demeris-backend-models/cns/endpoints"
+)
+
+// newNode returns a test server answering Tendermint RPC and Cosmos API probes for chainID.
+func newNode(t *testing.T, chainID string) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(endpoints.StatusPath, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":-1,"result":{"node_info":{"network":%q}}}`, chainID)
+	})
+	mux.HandleFunc(endpoints.NodeInfoPath, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"default_node_info":{"network":%q},"application_version":{}}`, chainID)
+	})
+
+	s := httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func TestProbe(t *testing.T) {
+	hub := newNode(t, "cosmoshub-4")
+
+	unwrapped := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"node_info":{"network":"cosmoshub-4"}}`))
+	}))
+	t.Cleanup(unwrapped.Close)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	t.Cleanup(failing.Close)
+
+	garbage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`<html></html>`))
+	}))
+	t.Cleanup(garbage.Close)
+
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	tests := []struct {
+		name    string
+		kind    endpoints.Kind
+		url     string
+		healthy bool
+	}{
+		{"tendermint rpc", endpoints.KindTendermintRPC, hub.URL, true},
+		{"tendermint rpc with trailing slash", endpoints.KindTendermintRPC, hub.URL + "/", true},
+		{"tendermint rpc without json-rpc envelope", endpoints.KindTendermintRPC, unwrapped.URL, true},
+		{"cosmos api", endpoints.KindCosmosAPI, hub.URL, true},
+		{"wrong chain id", endpoints.KindCosmosAPI, newNode(t, "osmosis-1").URL, false},
+		{"error status", endpoints.KindTendermintRPC, failing.URL, false},
+		{"invalid response", endpoints.KindTendermintRPC, garbage.URL, false},
+		{"unreachable", endpoints.KindCosmosAPI, closed.URL, false},
+		{"unknown kind", endpoints.Kind("grpc"), hub.URL, false},
+	}
+
+	p := endpoints.NewProber(nil)
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			res := p.Probe(context.Background(), tt.kind, tt.url, "cosmoshub-4")
+			require.Equal(t, tt.healthy, res.Healthy, res.Error)
+			require.Equal(t, tt.url, res.URL)
+			require.Equal(t, tt.kind, res.Kind)
+			require.Positive(t, res.Latency)
+			if !tt.healthy {
+				require.NotEmpty(t, res.Error)
+			}
+		})
+	}
+
+	res := p.Probe(context.Background(), endpoints.KindCosmosAPI, newNode(t, "osmosis-1").URL, "cosmoshub-4")
+	require.Equal(t, "osmosis-1", res.ChainID)
+	require.Contains(t, res.Error, endpoints.ErrChainIDMismatch.Error())
+
+	res = endpoints.Prober{}.Probe(context.Background(), endpoints.KindTendermintRPC, hub.URL, "cosmoshub-4")
+	require.True(t, res.Healthy, "zero-value Prober uses http.DefaultClient")
+}
+
+func TestProbeChain(t *testing.T) {
+	good, bad := newNode(t, "cosmoshub-4"), newNode(t, "theta-testnet-001")
+
+	c := cns.Chain{
+		NodeInfo: cns.NodeInfo{ChainID: "cosmoshub-4"},
+		PublicNodeEndpoints: cns.PublicNodeEndpoints{
+			TendermintRPC: []string{bad.URL, good.URL},
+			CosmosAPI:     []string{good.URL},
+		},
+	}
+
+	res := endpoints.NewProber(nil).ProbeChain(context.Background(), c)
+	require.Len(t, res, 3)
+	require.Equal(t, endpoints.KindTendermintRPC, res[0].Kind)
+	require.False(t, res[0].Healthy)
+	require.True(t, res[1].Healthy)
+	require.Equal(t, endpoints.KindCosmosAPI, res[2].Kind)
+	require.True(t, res[2].Healthy)
+}
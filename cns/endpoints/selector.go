@@ -0,0 +1,123 @@
+package endpoints
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrNoHealthyEndpoint is returned when a Selector has no healthy endpoint of the requested kind.
+var ErrNoHealthyEndpoint = errors.New("no healthy endpoint")
+
+// Strategy defines how a Selector picks among healthy endpoints.
+type Strategy int
+
+const (
+	// RoundRobin cycles through healthy endpoints.
+	RoundRobin Strategy = iota
+	// LowestLatency picks the healthy endpoint with the lowest probe latency.
+	LowestLatency
+	// Sticky keeps picking the same endpoint as long as it's healthy, then fails over to the
+	// first healthy one.
+	Sticky
+)
+
+// String implements the fmt.Stringer interface.
+func (s Strategy) String() string {
+	switch s {
+	case RoundRobin:
+		return "round-robin"
+	case LowestLatency:
+		return "lowest-latency"
+	case Sticky:
+		return "sticky"
+	default:
+		return fmt.Sprintf("Strategy(%d)", int(s))
+	}
+}
+
+// Selector picks endpoints out of the latest probe results.
+// It is safe for concurrent use.
+type Selector struct {
+	strategy Strategy
+
+	mu      sync.Mutex
+	results map[Kind][]Result
+	next    map[Kind]int    // round-robin position
+	current map[Kind]string // sticky endpoint
+}
+
+// NewSelector returns a Selector using strategy.
+func NewSelector(strategy Strategy) (*Selector, error) {
+	switch strategy {
+	case RoundRobin, LowestLatency, Sticky:
+	default:
+		return nil, fmt.Errorf("unknown selection strategy %s", strategy)
+	}
+
+	return &Selector{
+		strategy: strategy,
+		results:  map[Kind][]Result{},
+		next:     map[Kind]int{},
+		current:  map[Kind]string{},
+	}, nil
+}
+
+// Update replaces the probe results of the kinds present in results.
+func (s *Selector) Update(results []Result) {
+	byKind := map[Kind][]Result{}
+	for _, r := range results {
+		byKind[r.Kind] = append(byKind[r.Kind], r)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for k, r := range byKind {
+		s.results[k] = r
+	}
+}
+
+// Select returns the URL of a healthy endpoint of kind, according to the Selector strategy.
+func (s *Selector) Select(kind Kind) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var healthy []Result
+	for _, r := range s.results[kind] {
+		if r.Healthy {
+			healthy = append(healthy, r)
+		}
+	}
+
+	if len(healthy) == 0 {
+		return "", fmt.Errorf("%w: %s", ErrNoHealthyEndpoint, kind)
+	}
+
+	switch s.strategy {
+	case LowestLatency:
+		best := healthy[0]
+		for _, r := range healthy[1:] {
+			if r.Latency < best.Latency {
+				best = r
+			}
+		}
+
+		return best.URL, nil
+	case Sticky:
+		for _, r := range healthy {
+			if r.URL == s.current[kind] {
+				return r.URL, nil
+			}
+		}
+
+		s.current[kind] = healthy[0].URL
+
+		return healthy[0].URL, nil
+	default:
+		i := s.next[kind] % len(healthy)
+		s.next[kind] = i + 1
+
+		return healthy[i].URL, nil
+	}
+}
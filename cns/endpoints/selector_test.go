@@ -0,0 +1,82 @@
+package endpoints_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/emerishq/demeris-backend-models/cns/endpoints"
+)
+
+func result(url string, healthy bool, latency time.Duration) endpoints.Result {
+	return endpoints.Result{URL: url, Kind: endpoints.KindTendermintRPC, Healthy: healthy, Latency: latency}
+}
+
+var probeResults = []endpoints.Result{
+	result("a", true, 30*time.Millisecond),
+	result("b", false, time.Millisecond),
+	result("c", true, 10*time.Millisecond),
+	result("d", true, 20*time.Millisecond),
+}
+
+func selectN(t *testing.T, s *endpoints.Selector, n int) []string {
+	t.Helper()
+
+	var ret []string
+	for i := 0; i < n; i++ {
+		u, err := s.Select(endpoints.KindTendermintRPC)
+		require.NoError(t, err)
+		ret = append(ret, u)
+	}
+
+	return ret
+}
+
+func TestSelectorRoundRobin(t *testing.T) {
+	s, err := endpoints.NewSelector(endpoints.RoundRobin)
+	require.NoError(t, err)
+
+	s.Update(probeResults)
+	require.Equal(t, []string{"a", "c", "d", "a"}, selectN(t, s, 4))
+}
+
+func TestSelectorLowestLatency(t *testing.T) {
+	s, err := endpoints.NewSelector(endpoints.LowestLatency)
+	require.NoError(t, err)
+
+	s.Update(probeResults)
+	require.Equal(t, []string{"c", "c"}, selectN(t, s, 2))
+
+	s.Update([]endpoints.Result{result("a", true, 30*time.Millisecond), result("c", false, 0)})
+	require.Equal(t, []string{"a"}, selectN(t, s, 1))
+}
+
+func TestSelectorSticky(t *testing.T) {
+	s, err := endpoints.NewSelector(endpoints.Sticky)
+	require.NoError(t, err)
+
+	s.Update(probeResults)
+	require.Equal(t, []string{"a", "a"}, selectN(t, s, 2))
+
+	s.Update([]endpoints.Result{result("a", false, 0), result("c", true, 0), result("d", true, 0)})
+	require.Equal(t, []string{"c"}, selectN(t, s, 1))
+
+	s.Update([]endpoints.Result{result("a", true, 0), result("c", true, 0)})
+	require.Equal(t, []string{"c"}, selectN(t, s, 1), "sticky selector must not switch back once failed over")
+}
+
+func TestSelectorNoHealthyEndpoint(t *testing.T) {
+	s, err := endpoints.NewSelector(endpoints.RoundRobin)
+	require.NoError(t, err)
+
+	_, err = s.Select(endpoints.KindCosmosAPI)
+	require.ErrorIs(t, err, endpoints.ErrNoHealthyEndpoint)
+
+	s.Update([]endpoints.Result{result("b", false, 0)})
+	_, err = s.Select(endpoints.KindTendermintRPC)
+	require.ErrorIs(t, err, endpoints.ErrNoHealthyEndpoint)
+
+	_, err = endpoints.NewSelector(endpoints.Strategy(42))
+	require.Error(t, err)
+}
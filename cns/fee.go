@@ -0,0 +1,148 @@
+package cns
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// GasPriceLevel identifies one of the levels defined in GasPrice.
+type GasPriceLevel string
+
+const (
+	// GasPriceLow selects GasPrice.Low.
+	GasPriceLow GasPriceLevel = "low"
+	// GasPriceAverage selects GasPrice.Average.
+	GasPriceAverage GasPriceLevel = "average"
+	// GasPriceHigh selects GasPrice.High.
+	GasPriceHigh GasPriceLevel = "high"
+)
+
+// ErrNoFeeTokens is returned when estimating fees on a chain without fee tokens.
+var ErrNoFeeTokens = errors.New("no fee tokens defined")
+
+// Coin represents an amount of a given denom, expressed in base units.
+type Coin struct {
+	Denom  string `json:"denom"`
+	Amount string `json:"amount"`
+}
+
+// String implements the fmt.Stringer interface.
+func (c Coin) String() string {
+	return c.Amount + c.Denom
+}
+
+// Level returns the gas price for level l.
+func (a GasPrice) Level(l GasPriceLevel) (float64, error) {
+	switch l {
+	case GasPriceLow:
+		return a.Low, nil
+	case GasPriceAverage:
+		return a.Average, nil
+	case GasPriceHigh:
+		return a.High, nil
+	default:
+		return 0, fmt.Errorf("unknown gas price level %s", l)
+	}
+}
+
+type feeOptions struct {
+	gasAdjustment float64
+}
+
+// FeeOption customizes a fee estimation.
+type FeeOption func(*feeOptions)
+
+// WithGasAdjustment multiplies the gas limit by adjustment before computing the fee.
+func WithGasAdjustment(adjustment float64) FeeOption {
+	return func(o *feeOptions) {
+		o.gasAdjustment = adjustment
+	}
+}
+
+// EstimateFee returns the fee to be paid in d for gasLimit at the given gas price level.
+// The fee is rounded up to the nearest base unit.
+func (d Denom) EstimateFee(gasLimit uint64, level GasPriceLevel, opts ...FeeOption) (Coin, error) {
+	o := feeOptions{gasAdjustment: 1}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.gasAdjustment <= 0 {
+		return Coin{}, fmt.Errorf("gas adjustment must be positive, got %v", o.gasAdjustment)
+	}
+
+	if d.GasPriceLevels.Empty() {
+		return Coin{}, fmt.Errorf("denom %s has no gas price levels", d.Name)
+	}
+
+	price, err := d.GasPriceLevels.Level(level)
+	if err != nil {
+		return Coin{}, err
+	}
+
+	if price < 0 {
+		return Coin{}, fmt.Errorf("denom %s has negative %s gas price", d.Name, level)
+	}
+
+	fee, err := RatFromFloat(price)
+	if err != nil {
+		return Coin{}, err
+	}
+
+	adjustment, err := RatFromFloat(o.gasAdjustment)
+	if err != nil {
+		return Coin{}, err
+	}
+
+	fee.Mul(fee, adjustment)
+	fee.Mul(fee, new(big.Rat).SetUint64(gasLimit))
+
+	return Coin{
+		Denom:  d.Name,
+		Amount: ceilRat(fee).String(),
+	}, nil
+}
+
+// EstimateFees returns the fee to be paid for gasLimit at the given gas price level, for each of
+// c's FeeTokens.
+func (c Chain) EstimateFees(gasLimit uint64, level GasPriceLevel, opts ...FeeOption) ([]Coin, error) {
+	feeTokens := c.FeeTokens()
+	if len(feeTokens) == 0 {
+		return nil, fmt.Errorf("chain %s: %w", c.ChainName, ErrNoFeeTokens)
+	}
+
+	ret := make([]Coin, 0, len(feeTokens))
+	for _, d := range feeTokens {
+		fee, err := d.EstimateFee(gasLimit, level, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("chain %s: %w", c.ChainName, err)
+		}
+
+		ret = append(ret, fee)
+	}
+
+	return ret, nil
+}
+
+// RatFromFloat converts f through its shortest decimal representation, so that e.g. 0.025 is
+// handled as exactly 25/1000 instead of its binary approximation.
+func RatFromFloat(f float64) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(f, 'f', -1, 64))
+	if !ok {
+		return nil, fmt.Errorf("invalid number %v", f)
+	}
+
+	return r, nil
+}
+
+// ceilRat returns the smallest integer greater than or equal to r.
+func ceilRat(r *big.Rat) *big.Int {
+	q, m := new(big.Int).DivMod(r.Num(), r.Denom(), new(big.Int))
+	if m.Sign() != 0 {
+		q.Add(q, big.NewInt(1))
+	}
+
+	return q
+}
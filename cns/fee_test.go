@@ -0,0 +1,105 @@
+package cns_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+)
+
+var feeTestChain = cns.Chain{
+	ChainName: "cosmos-hub",
+	Denoms: cns.DenomList{
+		{
+			Name:           "uatom",
+			FeeToken:       true,
+			GasPriceLevels: cns.GasPrice{Low: 0.01, Average: 0.025, High: 0.03},
+		},
+		{
+			Name:           "uosmo",
+			FeeToken:       true,
+			GasPriceLevels: cns.GasPrice{Low: 0.0025, Average: 0.0033, High: 0.1},
+		},
+		{
+			Name: "stake",
+		},
+	},
+}
+
+func TestChainEstimateFees(t *testing.T) {
+	tests := []struct {
+		name      string
+		gasLimit  uint64
+		level     cns.GasPriceLevel
+		opts      []cns.FeeOption
+		expected  []cns.Coin
+		assertion require.ErrorAssertionFunc
+	}{
+		{
+			"average",
+			200000,
+			cns.GasPriceAverage,
+			nil,
+			[]cns.Coin{{Denom: "uatom", Amount: "5000"}, {Denom: "uosmo", Amount: "660"}},
+			require.NoError,
+		},
+		{
+			"low, rounded up",
+			123457,
+			cns.GasPriceLow,
+			nil,
+			[]cns.Coin{{Denom: "uatom", Amount: "1235"}, {Denom: "uosmo", Amount: "309"}},
+			require.NoError,
+		},
+		{
+			"high with gas adjustment",
+			100000,
+			cns.GasPriceHigh,
+			[]cns.FeeOption{cns.WithGasAdjustment(1.3)},
+			[]cns.Coin{{Denom: "uatom", Amount: "3900"}, {Denom: "uosmo", Amount: "13000"}},
+			require.NoError,
+		},
+		{
+			"zero gas",
+			0,
+			cns.GasPriceHigh,
+			nil,
+			[]cns.Coin{{Denom: "uatom", Amount: "0"}, {Denom: "uosmo", Amount: "0"}},
+			require.NoError,
+		},
+		{
+			"unknown level",
+			100000,
+			cns.GasPriceLevel("extreme"),
+			nil,
+			nil,
+			require.Error,
+		},
+		{
+			"non-positive gas adjustment",
+			100000,
+			cns.GasPriceAverage,
+			[]cns.FeeOption{cns.WithGasAdjustment(0)},
+			nil,
+			require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			fees, err := feeTestChain.EstimateFees(tt.gasLimit, tt.level, tt.opts...)
+			tt.assertion(t, err)
+			require.Equal(t, tt.expected, fees)
+		})
+	}
+}
+
+func TestChainEstimateFeesErrors(t *testing.T) {
+	_, err := cns.Chain{ChainName: "foo"}.EstimateFees(100000, cns.GasPriceAverage)
+	require.ErrorIs(t, err, cns.ErrNoFeeTokens)
+
+	_, err = cns.Chain{Denoms: cns.DenomList{{Name: "foo", FeeToken: true}}}.EstimateFees(100000, cns.GasPriceAverage)
+	require.Error(t, err)
+}
@@ -0,0 +1,264 @@
+package cns
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// ErrInvalidBankMsg is returned when a bank message is malformed.
+var ErrInvalidBankMsg = errors.New("invalid bank message")
+
+// BankMsg is a decoded bank module message transferring coins.
+type BankMsg interface {
+	// Recipients returns the coins received by each recipient of the message.
+	Recipients() []BankIO
+	// Validate returns an error if the message is malformed.
+	Validate() error
+}
+
+// BankIO is the address and coins of a bank transfer input or output.
+type BankIO struct {
+	Address string `json:"address"`
+	Coins   []Coin `json:"coins"`
+}
+
+// BankSend models the bank module MsgSend.
+type BankSend struct {
+	FromAddress string `json:"from_address"`
+	ToAddress   string `json:"to_address"`
+	Amount      []Coin `json:"amount"`
+}
+
+// Recipients implements the BankMsg interface.
+func (m BankSend) Recipients() []BankIO {
+	return []BankIO{{Address: m.ToAddress, Coins: m.Amount}}
+}
+
+// Validate implements the BankMsg interface.
+func (m BankSend) Validate() error {
+	if m.FromAddress == "" || m.ToAddress == "" {
+		return fmt.Errorf("%w: empty send address", ErrInvalidBankMsg)
+	}
+
+	if _, err := sumCoins(m.Amount); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// BankMultiSend models the bank module MsgMultiSend.
+type BankMultiSend struct {
+	Inputs  []BankIO `json:"inputs"`
+	Outputs []BankIO `json:"outputs"`
+}
+
+// Recipients implements the BankMsg interface.
+func (m BankMultiSend) Recipients() []BankIO {
+	return m.Outputs
+}
+
+// Validate implements the BankMsg interface.
+// As in the bank module, inputs and outputs must sum up to the same coins.
+func (m BankMultiSend) Validate() error {
+	if len(m.Inputs) == 0 || len(m.Outputs) == 0 {
+		return fmt.Errorf("%w: multi-send without inputs or outputs", ErrInvalidBankMsg)
+	}
+
+	in, err := sumIOs(m.Inputs)
+	if err != nil {
+		return err
+	}
+
+	out, err := sumIOs(m.Outputs)
+	if err != nil {
+		return err
+	}
+
+	if len(in) != len(out) {
+		return fmt.Errorf("%w: multi-send inputs and outputs don't match", ErrInvalidBankMsg)
+	}
+
+	for denom, amount := range in {
+		if o, ok := out[denom]; !ok || o.Cmp(amount) != 0 {
+			return fmt.Errorf("%w: multi-send inputs and outputs don't match for %s", ErrInvalidBankMsg, denom)
+		}
+	}
+
+	return nil
+}
+
+// FeeVerification is the outcome of a fee payment verification.
+type FeeVerification struct {
+	Paid     bool   `json:"paid"`
+	Received []Coin `json:"received"`          // amounts received by DemerisAddresses in FeeTokens denoms, sorted by denom
+	Covered  *Coin  `json:"covered,omitempty"` // the first required fee covered by Received
+}
+
+// VerifyFeePayment returns whether msgs paid a fee to one of c's DemerisAddresses.
+// Recipients are matched on their decoded address bytes, so that e.g. uppercase bech32 addresses
+// are accounted for; recipients that aren't c's account addresses are ignored.
+// Only amounts in c's FeeTokens denoms are accounted for, summed over all DemerisAddresses.
+// The fee is paid if the received amount covers any of required, e.g. the fees returned by
+// EstimateFees; if required is empty, any positive amount is accepted.
+func (c Chain) VerifyFeePayment(required []Coin, msgs ...BankMsg) (FeeVerification, error) {
+	feeDenoms := map[string]struct{}{}
+	for _, d := range c.FeeTokens() {
+		feeDenoms[d.Name] = struct{}{}
+	}
+
+	if len(feeDenoms) == 0 {
+		return FeeVerification{}, fmt.Errorf("chain %s: %w", c.ChainName, ErrNoFeeTokens)
+	}
+
+	for _, r := range required {
+		if _, ok := feeDenoms[r.Denom]; !ok {
+			return FeeVerification{}, fmt.Errorf("chain %s: required fee denom %s is not a fee token", c.ChainName, r.Denom)
+		}
+	}
+
+	bech32 := c.NodeInfo.Bech32Config
+	accepted := map[string]struct{}{}
+	for _, a := range c.DemerisAddresses {
+		bz, err := bech32.DecodeAddress(a, RoleAccount)
+		if err != nil {
+			return FeeVerification{}, fmt.Errorf("chain %s: invalid demeris address, %w", c.ChainName, err)
+		}
+
+		accepted[string(bz)] = struct{}{}
+	}
+
+	received := map[string]*big.Int{}
+	for _, msg := range msgs {
+		if msg == nil {
+			return FeeVerification{}, fmt.Errorf("%w: nil message", ErrInvalidBankMsg)
+		}
+
+		if err := msg.Validate(); err != nil {
+			return FeeVerification{}, err
+		}
+
+		for _, o := range msg.Recipients() {
+			bz, err := bech32.DecodeAddress(o.Address, RoleAccount)
+			if err != nil {
+				continue
+			}
+
+			if _, ok := accepted[string(bz)]; !ok {
+				continue
+			}
+
+			for _, coin := range o.Coins {
+				if _, ok := feeDenoms[coin.Denom]; !ok {
+					continue
+				}
+
+				// amounts were checked by Validate.
+				amount, _ := new(big.Int).SetString(coin.Amount, 10)
+				if received[coin.Denom] == nil {
+					received[coin.Denom] = new(big.Int)
+				}
+
+				received[coin.Denom].Add(received[coin.Denom], amount)
+			}
+		}
+	}
+
+	denoms := make([]string, 0, len(received))
+	for denom := range received {
+		denoms = append(denoms, denom)
+	}
+
+	sort.Strings(denoms)
+
+	var ret FeeVerification
+	for _, denom := range denoms {
+		ret.Received = append(ret.Received, Coin{Denom: denom, Amount: received[denom].String()})
+	}
+
+	if len(required) == 0 {
+		for _, r := range ret.Received {
+			if received[r.Denom].Sign() > 0 {
+				r := r
+				ret.Paid = true
+				ret.Covered = &r
+				break
+			}
+		}
+
+		return ret, nil
+	}
+
+	for _, r := range required {
+		amount, err := parseCoinAmount(r)
+		if err != nil {
+			return FeeVerification{}, err
+		}
+
+		if got, ok := received[r.Denom]; ok && got.Cmp(amount) >= 0 {
+			r := r
+			ret.Paid = true
+			ret.Covered = &r
+			break
+		}
+	}
+
+	return ret, nil
+}
+
+func parseCoinAmount(c Coin) (*big.Int, error) {
+	if c.Denom == "" {
+		return nil, fmt.Errorf("%w: empty denom", ErrInvalidAmount)
+	}
+
+	n, ok := new(big.Int).SetString(c.Amount, 10)
+	if !ok || n.Sign() < 0 {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidAmount, c.String())
+	}
+
+	return n, nil
+}
+
+func sumCoins(coins []Coin) (map[string]*big.Int, error) {
+	ret := map[string]*big.Int{}
+	for _, c := range coins {
+		n, err := parseCoinAmount(c)
+		if err != nil {
+			return nil, err
+		}
+
+		if ret[c.Denom] == nil {
+			ret[c.Denom] = new(big.Int)
+		}
+
+		ret[c.Denom].Add(ret[c.Denom], n)
+	}
+
+	return ret, nil
+}
+
+func sumIOs(ios []BankIO) (map[string]*big.Int, error) {
+	ret := map[string]*big.Int{}
+	for _, io := range ios {
+		if io.Address == "" {
+			return nil, fmt.Errorf("%w: empty multi-send address", ErrInvalidBankMsg)
+		}
+
+		sum, err := sumCoins(io.Coins)
+		if err != nil {
+			return nil, err
+		}
+
+		for denom, n := range sum {
+			if ret[denom] == nil {
+				ret[denom] = new(big.Int)
+			}
+
+			ret[denom].Add(ret[denom], n)
+		}
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,187 @@
+package cns_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+)
+
+const (
+	feeAddr     = "cosmos1vejk2qgqqqqqqqqqqqqqqqqqqqqqqqqquwmyav"
+	feeAddr2    = "cosmos1vejk2qsqqqqqqqqqqqqqqqqqqqqqqqqqa0ww3j"
+	someoneAddr = "cosmos1wdhk6et0dej47h6lta047h6lta047h6ls09q7j"
+)
+
+var feeChain = cns.Chain{
+	ChainName:        "cosmos-hub",
+	DemerisAddresses: []string{feeAddr, feeAddr2},
+	NodeInfo: cns.NodeInfo{
+		Bech32Config: cns.Bech32Config{MainPrefix: "cosmos", PrefixAccount: "acc"},
+	},
+	Denoms: cns.DenomList{
+		{Name: "uatom", FeeToken: true},
+		{Name: "ustake", FeeToken: true},
+		{Name: "uother"},
+	},
+}
+
+func send(to string, coins ...cns.Coin) cns.BankSend {
+	return cns.BankSend{FromAddress: "cosmos1user", ToAddress: to, Amount: coins}
+}
+
+func TestVerifyFeePayment(t *testing.T) {
+	required := []cns.Coin{{Denom: "uatom", Amount: "5000"}, {Denom: "ustake", Amount: "100"}}
+
+	tests := []struct {
+		name      string
+		required  []cns.Coin
+		msgs      []cns.BankMsg
+		paid      bool
+		received  []cns.Coin
+		assertion require.ErrorAssertionFunc
+	}{
+		{
+			"exact fee",
+			required,
+			[]cns.BankMsg{send(feeAddr, cns.Coin{Denom: "uatom", Amount: "5000"})},
+			true,
+			[]cns.Coin{{Denom: "uatom", Amount: "5000"}},
+			require.NoError,
+		},
+		{
+			"uppercase recipient address",
+			required,
+			[]cns.BankMsg{send(strings.ToUpper(feeAddr), cns.Coin{Denom: "uatom", Amount: "5000"})},
+			true,
+			[]cns.Coin{{Denom: "uatom", Amount: "5000"}},
+			require.NoError,
+		},
+		{
+			"fee in second fee token",
+			required,
+			[]cns.BankMsg{send(feeAddr2, cns.Coin{Denom: "uatom", Amount: "10"}, cns.Coin{Denom: "ustake", Amount: "150"})},
+			true,
+			[]cns.Coin{{Denom: "uatom", Amount: "10"}, {Denom: "ustake", Amount: "150"}},
+			require.NoError,
+		},
+		{
+			"fee split across messages and addresses",
+			required,
+			[]cns.BankMsg{
+				send(feeAddr, cns.Coin{Denom: "uatom", Amount: "2500"}),
+				cns.BankMultiSend{
+					Inputs: []cns.BankIO{{Address: "cosmos1user", Coins: []cns.Coin{{Denom: "uatom", Amount: "3500"}}}},
+					Outputs: []cns.BankIO{
+						{Address: feeAddr2, Coins: []cns.Coin{{Denom: "uatom", Amount: "2500"}}},
+						{Address: someoneAddr, Coins: []cns.Coin{{Denom: "uatom", Amount: "1000"}}},
+					},
+				},
+			},
+			true,
+			[]cns.Coin{{Denom: "uatom", Amount: "5000"}},
+			require.NoError,
+		},
+		{
+			"insufficient fee",
+			required,
+			[]cns.BankMsg{send(feeAddr, cns.Coin{Denom: "uatom", Amount: "4999"})},
+			false,
+			[]cns.Coin{{Denom: "uatom", Amount: "4999"}},
+			require.NoError,
+		},
+		{
+			"paid to another address",
+			required,
+			[]cns.BankMsg{send(someoneAddr, cns.Coin{Denom: "uatom", Amount: "5000"})},
+			false,
+			nil,
+			require.NoError,
+		},
+		{
+			"paid in non fee token",
+			required,
+			[]cns.BankMsg{send(feeAddr, cns.Coin{Denom: "uother", Amount: "5000"})},
+			false,
+			nil,
+			require.NoError,
+		},
+		{
+			"no required amount",
+			nil,
+			[]cns.BankMsg{send(feeAddr, cns.Coin{Denom: "ustake", Amount: "1"})},
+			true,
+			[]cns.Coin{{Denom: "ustake", Amount: "1"}},
+			require.NoError,
+		},
+		{
+			"required denom not a fee token",
+			[]cns.Coin{{Denom: "uother", Amount: "1"}},
+			[]cns.BankMsg{send(feeAddr, cns.Coin{Denom: "uother", Amount: "1"})},
+			false,
+			nil,
+			require.Error,
+		},
+		{
+			"invalid amount",
+			required,
+			[]cns.BankMsg{send(feeAddr, cns.Coin{Denom: "uatom", Amount: "1.5"})},
+			false,
+			nil,
+			require.Error,
+		},
+		{
+			"unbalanced multi-send",
+			required,
+			[]cns.BankMsg{cns.BankMultiSend{
+				Inputs:  []cns.BankIO{{Address: "cosmos1user", Coins: []cns.Coin{{Denom: "uatom", Amount: "1"}}}},
+				Outputs: []cns.BankIO{{Address: feeAddr, Coins: []cns.Coin{{Denom: "uatom", Amount: "5000"}}}},
+			}},
+			false,
+			nil,
+			require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := feeChain.VerifyFeePayment(tt.required, tt.msgs...)
+			tt.assertion(t, err)
+			require.Equal(t, tt.paid, res.Paid)
+			require.Equal(t, tt.received, res.Received)
+			require.Equal(t, tt.paid, res.Covered != nil)
+		})
+	}
+
+	_, err := cns.Chain{ChainName: "foo"}.VerifyFeePayment(nil)
+	require.ErrorIs(t, err, cns.ErrNoFeeTokens)
+
+	_, err = feeChain.VerifyFeePayment(required, nil)
+	require.ErrorIs(t, err, cns.ErrInvalidBankMsg)
+
+	invalid := feeChain
+	invalid.DemerisAddresses = []string{"cosmos1fee"}
+	_, err = invalid.VerifyFeePayment(required)
+	require.Error(t, err)
+}
+
+func TestBankMultiSendValidate(t *testing.T) {
+	io := func(addr, amount string) cns.BankIO {
+		return cns.BankIO{Address: addr, Coins: []cns.Coin{{Denom: "uatom", Amount: amount}}}
+	}
+
+	require.NoError(t, cns.BankMultiSend{
+		Inputs:  []cns.BankIO{io("a", "3"), io("b", "2")},
+		Outputs: []cns.BankIO{io("c", "5")},
+	}.Validate())
+
+	require.ErrorIs(t, cns.BankMultiSend{Outputs: []cns.BankIO{io("c", "5")}}.Validate(), cns.ErrInvalidBankMsg)
+	require.ErrorIs(t, cns.BankMultiSend{
+		Inputs:  []cns.BankIO{io("a", "5")},
+		Outputs: []cns.BankIO{io("", "5")},
+	}.Validate(), cns.ErrInvalidBankMsg)
+	require.ErrorIs(t, cns.BankSend{ToAddress: "a"}.Validate(), cns.ErrInvalidBankMsg)
+}
@@ -0,0 +1,87 @@
+package cns
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// JSONB represents a database-enabled JSON column holding a value of type T.
+type JSONB[T any] struct {
+	Data T
+}
+
+// NewJSONB returns a JSONB holding data.
+func NewJSONB[T any](data T) JSONB[T] {
+	return JSONB[T]{Data: data}
+}
+
+// Scan is the sql.Scanner implementation for JSONB.
+func (j *JSONB[T]) Scan(value interface{}) error {
+	var data T
+	if err := scanJSON(value, &data); err != nil {
+		return err
+	}
+
+	j.Data = data
+
+	return nil
+}
+
+// Value is the driver.Valuer implementation for JSONB.
+func (j JSONB[T]) Value() (driver.Value, error) {
+	return jsonValue(j.Data)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (j JSONB[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(j.Data)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (j *JSONB[T]) UnmarshalJSON(bytes []byte) error {
+	return json.Unmarshal(bytes, &j.Data)
+}
+
+// scanJSONB scans value into dst through JSONB, so that named JSON column types share its
+// semantics: dst is reset before decoding, and a nil value yields T's zero value.
+func scanJSONB[T any](value interface{}, dst *T) error {
+	var j JSONB[T]
+	if err := j.Scan(value); err != nil {
+		return err
+	}
+
+	*dst = j.Data
+
+	return nil
+}
+
+// scanJSON unmarshals a JSON database value into dst.
+// Drivers return JSON columns either as []byte or string, both are accepted.
+// A nil value leaves dst untouched.
+func scanJSON(value interface{}, dst interface{}) error {
+	var b []byte
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan value of type %T as JSON", value)
+	}
+
+	return json.Unmarshal(b, dst)
+}
+
+// jsonValue marshals v into a JSON database value.
+// The value is returned as string, since some drivers encode []byte values as bytea.
+func jsonValue(v interface{}) (driver.Value, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(b), nil
+}
@@ -0,0 +1,151 @@
+package cns_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+)
+
+type jsonColumn interface {
+	sql.Scanner
+	driver.Valuer
+}
+
+func TestJSONColumnsRoundTrip(t *testing.T) {
+	thresh := int64(42)
+
+	tests := []struct {
+		name     string
+		value    driver.Valuer
+		scanned  func() jsonColumn
+		expected interface{}
+		null     interface{}
+	}{
+		{
+			"NodeInfo",
+			cns.NodeInfo{
+				Endpoint:     "http://localhost:26657",
+				ChainID:      "cosmoshub-4",
+				Bech32Config: cns.Bech32Config{MainPrefix: "cosmos", PrefixAccount: "acc"},
+			},
+			func() jsonColumn { return &cns.NodeInfo{} },
+			&cns.NodeInfo{
+				Endpoint:     "http://localhost:26657",
+				ChainID:      "cosmoshub-4",
+				Bech32Config: cns.Bech32Config{MainPrefix: "cosmos", PrefixAccount: "acc"},
+			},
+			new(cns.NodeInfo),
+		},
+		{
+			"PublicNodeEndpoints",
+			cns.PublicNodeEndpoints{TendermintRPC: []string{"https://rpc:443"}, CosmosAPI: []string{"https://api:443"}},
+			func() jsonColumn { return &cns.PublicNodeEndpoints{} },
+			&cns.PublicNodeEndpoints{TendermintRPC: []string{"https://rpc:443"}, CosmosAPI: []string{"https://api:443"}},
+			new(cns.PublicNodeEndpoints),
+		},
+		{
+			"GasPrice",
+			cns.GasPrice{Low: 0.01, Average: 0.025, High: 0.04},
+			func() jsonColumn { return &cns.GasPrice{} },
+			&cns.GasPrice{Low: 0.01, Average: 0.025, High: 0.04},
+			new(cns.GasPrice),
+		},
+		{
+			"DenomList",
+			cns.DenomList{{Name: "uatom", Precision: 6, MinimumThreshRelayerBalance: &thresh}},
+			func() jsonColumn { return &cns.DenomList{} },
+			&cns.DenomList{{Name: "uatom", Precision: 6, MinimumThreshRelayerBalance: &thresh}},
+			new(cns.DenomList),
+		},
+		{
+			"DbStringMap",
+			cns.DbStringMap{"osmosis": "channel-141"},
+			func() jsonColumn { return &cns.DbStringMap{} },
+			&cns.DbStringMap{"osmosis": "channel-141"},
+			new(cns.DbStringMap),
+		},
+		{
+			"JSONB",
+			cns.NewJSONB([]string{"a", "b"}),
+			func() jsonColumn { return &cns.JSONB[[]string]{} },
+			&cns.JSONB[[]string]{Data: []string{"a", "b"}},
+			new(cns.JSONB[[]string]),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := tt.value.Value()
+			require.NoError(t, err)
+			require.IsType(t, "", v)
+
+			// pgx-based drivers return JSON columns as string
+			fromString := tt.scanned()
+			require.NoError(t, fromString.Scan(v))
+			require.Equal(t, tt.expected, fromString)
+
+			// lib/pq returns JSON columns as []byte
+			fromBytes := tt.scanned()
+			require.NoError(t, fromBytes.Scan([]byte(v.(string))))
+			require.Equal(t, tt.expected, fromBytes)
+
+			// NULL columns reset reused scan targets
+			require.NoError(t, fromBytes.Scan(nil))
+			require.Equal(t, tt.null, fromBytes)
+		})
+	}
+}
+
+func TestJSONBScan(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     interface{}
+		expected  map[string]int
+		assertion require.ErrorAssertionFunc
+	}{
+		{
+			"bytes",
+			[]byte(`{"a":1}`),
+			map[string]int{"a": 1},
+			require.NoError,
+		},
+		{
+			"string",
+			`{"a":1}`,
+			map[string]int{"a": 1},
+			require.NoError,
+		},
+		{
+			"nil",
+			nil,
+			nil,
+			require.NoError,
+		},
+		{
+			"unsupported type",
+			42,
+			nil,
+			require.Error,
+		},
+		{
+			"invalid JSON",
+			"foo",
+			nil,
+			require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			j := cns.JSONB[map[string]int]{}
+			tt.assertion(t, j.Scan(tt.value))
+			require.Equal(t, tt.expected, j.Data)
+		})
+	}
+}
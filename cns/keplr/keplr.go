@@ -0,0 +1,260 @@
+// Package keplr maps CNS chains to the Keplr wallet "suggest chain" ChainInfo document.
+// See https://docs.keplr.app/api/suggest-chain.html.
+package keplr
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"golang.org/x/mod/semver"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+)
+
+const (
+	featureStargate    = "stargate"
+	featureIBCTransfer = "ibc-transfer"
+
+	// stargateSDKVersion is the first Cosmos SDK version supporting the features above.
+	stargateSDKVersion = "v0.40.0"
+
+	// maxCoinDecimals is the maximum number of decimals Keplr supports.
+	maxCoinDecimals = 18
+)
+
+// ChainInfo is the document accepted by Keplr's experimentalSuggestChain.
+type ChainInfo struct {
+	RPC           string        `json:"rpc"`
+	REST          string        `json:"rest"`
+	ChainID       string        `json:"chainId"`
+	ChainName     string        `json:"chainName"`
+	StakeCurrency Currency      `json:"stakeCurrency"`
+	BIP44         BIP44         `json:"bip44"`
+	Bech32Config  Bech32Config  `json:"bech32Config"`
+	Currencies    []Currency    `json:"currencies"`
+	FeeCurrencies []FeeCurrency `json:"feeCurrencies"`
+	GasPriceStep  *GasPriceStep `json:"gasPriceStep,omitempty"` // deprecated in favor of FeeCurrency.GasPriceStep, kept for older Keplr versions
+	CoinType      uint32        `json:"coinType,omitempty"`
+	Features      []string      `json:"features,omitempty"`
+}
+
+// BIP44 holds the coin type used to derive keys.
+type BIP44 struct {
+	CoinType uint32 `json:"coinType"`
+}
+
+// Bech32Config holds the bech32 prefixes of a chain.
+type Bech32Config struct {
+	Bech32PrefixAccAddr  string `json:"bech32PrefixAccAddr"`
+	Bech32PrefixAccPub   string `json:"bech32PrefixAccPub"`
+	Bech32PrefixValAddr  string `json:"bech32PrefixValAddr"`
+	Bech32PrefixValPub   string `json:"bech32PrefixValPub"`
+	Bech32PrefixConsAddr string `json:"bech32PrefixConsAddr"`
+	Bech32PrefixConsPub  string `json:"bech32PrefixConsPub"`
+}
+
+// Currency represents a token of a chain.
+type Currency struct {
+	CoinDenom        string `json:"coinDenom"`
+	CoinMinimalDenom string `json:"coinMinimalDenom"`
+	CoinDecimals     int64  `json:"coinDecimals"`
+	CoinGeckoID      string `json:"coinGeckoId,omitempty"`
+	CoinImageURL     string `json:"coinImageUrl,omitempty"`
+}
+
+// FeeCurrency represents a token usable to pay fees.
+type FeeCurrency struct {
+	Currency
+	GasPriceStep *GasPriceStep `json:"gasPriceStep,omitempty"`
+}
+
+// GasPriceStep holds the gas prices proposed by Keplr.
+type GasPriceStep struct {
+	Low     float64 `json:"low"`
+	Average float64 `json:"average"`
+	High    float64 `json:"high"`
+}
+
+// FromChain returns the Keplr ChainInfo for c.
+func FromChain(c cns.Chain) (ChainInfo, error) {
+	if len(c.PublicNodeEndpoints.TendermintRPC) == 0 || len(c.PublicNodeEndpoints.CosmosAPI) == 0 {
+		return ChainInfo{}, fmt.Errorf("chain %s has no public node endpoints", c.ChainName)
+	}
+
+	coinType, err := c.CoinType()
+	if err != nil {
+		return ChainInfo{}, err
+	}
+
+	b := c.NodeInfo.Bech32Config
+	ci := ChainInfo{
+		RPC:       c.PublicNodeEndpoints.TendermintRPC[0],
+		REST:      c.PublicNodeEndpoints.CosmosAPI[0],
+		ChainID:   c.NodeInfo.ChainID,
+		ChainName: c.DisplayName,
+		BIP44:     BIP44{CoinType: coinType},
+		CoinType:  coinType,
+		Bech32Config: Bech32Config{
+			Bech32PrefixAccAddr:  b.Bech32PrefixAccAddr(),
+			Bech32PrefixAccPub:   b.Bech32PrefixAccPub(),
+			Bech32PrefixValAddr:  b.Bech32PrefixValAddr(),
+			Bech32PrefixValPub:   b.Bech32PrefixValPub(),
+			Bech32PrefixConsAddr: b.Bech32PrefixConsAddr(),
+			Bech32PrefixConsPub:  b.Bech32PrefixConsPub(),
+		},
+	}
+
+	stakeFound := false
+	for _, d := range c.Denoms {
+		cur := currency(d)
+		ci.Currencies = append(ci.Currencies, cur)
+
+		if d.Stakable && !stakeFound {
+			ci.StakeCurrency = cur
+			stakeFound = true
+		}
+
+		if d.FeeToken {
+			fc := FeeCurrency{Currency: cur}
+			if !d.GasPriceLevels.Empty() {
+				fc.GasPriceStep = &GasPriceStep{
+					Low:     d.GasPriceLevels.Low,
+					Average: d.GasPriceLevels.Average,
+					High:    d.GasPriceLevels.High,
+				}
+			}
+
+			ci.FeeCurrencies = append(ci.FeeCurrencies, fc)
+		}
+	}
+
+	if !stakeFound {
+		return ChainInfo{}, fmt.Errorf("chain %s has no stakable denom", c.ChainName)
+	}
+
+	if len(ci.FeeCurrencies) > 0 {
+		ci.GasPriceStep = ci.FeeCurrencies[0].GasPriceStep
+	}
+
+	if semver.IsValid(c.CosmosSDKVersion) && semver.Compare(c.CosmosSDKVersion, stargateSDKVersion) >= 0 {
+		ci.Features = []string{featureStargate, featureIBCTransfer}
+	}
+
+	if err := ci.Validate(); err != nil {
+		return ChainInfo{}, fmt.Errorf("chain %s: %w", c.ChainName, err)
+	}
+
+	return ci, nil
+}
+
+// currency returns d as a Keplr Currency, which displays amounts in d's display unit:
+// its name is d's Ticker, or else its DisplayUnit, and its decimals are the display unit's exponent.
+func currency(d cns.Denom) Currency {
+	coinDenom := d.Ticker
+	if coinDenom == "" {
+		coinDenom = strings.ToUpper(d.DisplayUnit)
+	}
+
+	if coinDenom == "" {
+		coinDenom = d.DisplayName
+	}
+
+	if coinDenom == "" {
+		coinDenom = d.Name
+	}
+
+	decimals := d.Precision
+	if d.DisplayUnit != "" {
+		if u, err := d.Unit(d.DisplayUnit); err == nil {
+			decimals = int64(u.Exponent)
+		}
+	}
+
+	return Currency{
+		CoinDenom:        coinDenom,
+		CoinMinimalDenom: d.Name,
+		CoinDecimals:     decimals,
+		CoinGeckoID:      d.PriceID,
+		CoinImageURL:     d.Logo,
+	}
+}
+
+// Validate returns an error if ci would be rejected by Keplr.
+func (ci ChainInfo) Validate() error {
+	if err := validateURL(ci.RPC); err != nil {
+		return fmt.Errorf("invalid rpc, %w", err)
+	}
+
+	if err := validateURL(ci.REST); err != nil {
+		return fmt.Errorf("invalid rest, %w", err)
+	}
+
+	if ci.ChainID == "" {
+		return errors.New("chainId is empty")
+	}
+
+	if ci.ChainName == "" {
+		return errors.New("chainName is empty")
+	}
+
+	if ci.Bech32Config.Bech32PrefixAccAddr == "" {
+		return errors.New("bech32PrefixAccAddr is empty")
+	}
+
+	if len(ci.Currencies) == 0 {
+		return errors.New("currencies is empty")
+	}
+
+	if len(ci.FeeCurrencies) == 0 {
+		return errors.New("feeCurrencies is empty")
+	}
+
+	for _, c := range ci.Currencies {
+		if err := c.validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, fc := range ci.FeeCurrencies {
+		if err := fc.validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := ci.StakeCurrency.validate(); err != nil {
+		return fmt.Errorf("invalid stakeCurrency, %w", err)
+	}
+
+	return nil
+}
+
+func (c Currency) validate() error {
+	if c.CoinDenom == "" || c.CoinMinimalDenom == "" {
+		return fmt.Errorf("currency %q has empty denom", c.CoinMinimalDenom)
+	}
+
+	if c.CoinDecimals < 0 || c.CoinDecimals > maxCoinDecimals {
+		return fmt.Errorf("currency %s has %d decimals, must be between 0 and %d", c.CoinMinimalDenom, c.CoinDecimals, maxCoinDecimals)
+	}
+
+	return nil
+}
+
+func validateURL(u string) error {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return err
+	}
+
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return fmt.Errorf("unsupported URL scheme %q", parsed.Scheme)
+	}
+
+	if parsed.Host == "" {
+		return fmt.Errorf("URL %s has no host", u)
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package keplr_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+	"github.com/emerishq/demeris-backend-models/cns/keplr"
+)
+
+func keplrTestChain() cns.Chain {
+	return cns.Chain{
+		ChainName:   "cosmos-hub",
+		DisplayName: "Cosmos Hub",
+		Denoms: cns.DenomList{
+			{
+				Name:           "uatom",
+				DisplayName:    "ATOM",
+				Ticker:         "ATOM",
+				Logo:           "https://example.com/atom.png",
+				Precision:      6,
+				PriceID:        "cosmos",
+				Stakable:       true,
+				FeeToken:       true,
+				GasPriceLevels: cns.GasPrice{Low: 0.01, Average: 0.025, High: 0.03},
+			},
+			{
+				Name:      "ufoo",
+				Precision: 6,
+			},
+			{
+				Name:        "nbar",
+				Precision:   9,
+				DisplayUnit: "bar",
+				Units:       cns.DenomUnits{{Denom: "nbar"}, {Denom: "ubar", Exponent: 3}, {Denom: "bar", Exponent: 9}},
+			},
+		},
+		NodeInfo: cns.NodeInfo{
+			ChainID: "cosmoshub-4",
+			Bech32Config: cns.Bech32Config{
+				MainPrefix:      "cosmos",
+				PrefixAccount:   "acc",
+				PrefixValidator: "val",
+				PrefixConsensus: "cons",
+				PrefixPublic:    "pub",
+				PrefixOperator:  "oper",
+			},
+		},
+		DerivationPath: "m/44'/118'/0'/0/0",
+		PublicNodeEndpoints: cns.PublicNodeEndpoints{
+			TendermintRPC: []string{"https://rpc.cosmos.network:443"},
+			CosmosAPI:     []string{"https://api.cosmos.network:443"},
+		},
+		CosmosSDKVersion: "v0.45.1",
+	}
+}
+
+func TestFromChain(t *testing.T) {
+	ci, err := keplr.FromChain(keplrTestChain())
+	require.NoError(t, err)
+
+	atom := keplr.Currency{
+		CoinDenom:        "ATOM",
+		CoinMinimalDenom: "uatom",
+		CoinDecimals:     6,
+		CoinGeckoID:      "cosmos",
+		CoinImageURL:     "https://example.com/atom.png",
+	}
+	gasPriceStep := &keplr.GasPriceStep{Low: 0.01, Average: 0.025, High: 0.03}
+
+	require.Equal(t, keplr.ChainInfo{
+		RPC:           "https://rpc.cosmos.network:443",
+		REST:          "https://api.cosmos.network:443",
+		ChainID:       "cosmoshub-4",
+		ChainName:     "Cosmos Hub",
+		StakeCurrency: atom,
+		BIP44:         keplr.BIP44{CoinType: 118},
+		Bech32Config: keplr.Bech32Config{
+			Bech32PrefixAccAddr:  "cosmos",
+			Bech32PrefixAccPub:   "cosmospub",
+			Bech32PrefixValAddr:  "cosmosvaloper",
+			Bech32PrefixValPub:   "cosmosvaloperpub",
+			Bech32PrefixConsAddr: "cosmosvalcons",
+			Bech32PrefixConsPub:  "cosmosvalconspub",
+		},
+		Currencies: []keplr.Currency{
+			atom,
+			{CoinDenom: "ufoo", CoinMinimalDenom: "ufoo", CoinDecimals: 6},
+			{CoinDenom: "BAR", CoinMinimalDenom: "nbar", CoinDecimals: 9},
+		},
+		FeeCurrencies: []keplr.FeeCurrency{{Currency: atom, GasPriceStep: gasPriceStep}},
+		GasPriceStep:  gasPriceStep,
+		CoinType:      118,
+		Features:      []string{"stargate", "ibc-transfer"},
+	}, ci)
+
+	b, err := json.Marshal(ci)
+	require.NoError(t, err)
+	require.Contains(t, string(b), `"feeCurrencies":[{"coinDenom":"ATOM","coinMinimalDenom":"uatom","coinDecimals":6,"coinGeckoId":"cosmos","coinImageUrl":"https://example.com/atom.png","gasPriceStep":{"low":0.01,"average":0.025,"high":0.03}}]`)
+}
+
+func TestFromChainErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(c *cns.Chain)
+	}{
+		{
+			"no public node endpoints",
+			func(c *cns.Chain) { c.PublicNodeEndpoints = cns.PublicNodeEndpoints{} },
+		},
+		{
+			"invalid derivation path",
+			func(c *cns.Chain) { c.DerivationPath = "foo" },
+		},
+		{
+			"no stakable denom",
+			func(c *cns.Chain) { c.Denoms[0].Stakable = false },
+		},
+		{
+			"no fee token",
+			func(c *cns.Chain) { c.Denoms[0].FeeToken = false },
+		},
+		{
+			"too many decimals",
+			func(c *cns.Chain) { c.Denoms[1].Precision = 24 },
+		},
+		{
+			"invalid rpc",
+			func(c *cns.Chain) { c.PublicNodeEndpoints.TendermintRPC = []string{"tcp://localhost:26657"} },
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			c := keplrTestChain()
+			tt.mutate(&c)
+
+			_, err := keplr.FromChain(c)
+			require.Error(t, err)
+		})
+	}
+}
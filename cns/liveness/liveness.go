@@ -0,0 +1,198 @@
+// Package liveness evaluates whether chains are producing blocks, by comparing the time of their
+// latest block with their CNS ValidBlockThresh.
+package liveness
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+	"github.com/emerishq/demeris-backend-models/tracelistener"
+)
+
+// Health is the liveness state of a chain.
+type Health string
+
+const (
+	// HealthLive means the chain produced a block within ValidBlockThresh.
+	HealthLive Health = "live"
+	// HealthLagging means the chain latest block is older than ValidBlockThresh, but not enough to consider the chain halted.
+	HealthLagging Health = "lagging"
+	// HealthHalted means the chain hasn't produced blocks for more than HaltFactor times ValidBlockThresh.
+	HealthHalted Health = "halted"
+	// HealthUnknown means liveness cannot be evaluated, e.g. no block time is known for the chain.
+	HealthUnknown Health = "unknown"
+)
+
+// Clock provides the current time.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc is a function implementing the Clock interface.
+type ClockFunc func() time.Time
+
+// Now implements the Clock interface.
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// SystemClock is the Clock returning the system time.
+var SystemClock Clock = ClockFunc(time.Now)
+
+// Status is the liveness of a chain at a given time.
+type Status struct {
+	ChainName string        `json:"chain_name"`
+	Health    Health        `json:"health"`
+	LastBlock time.Time     `json:"last_block"` // zero if unknown
+	Age       time.Duration `json:"age"`        // time elapsed since LastBlock at CheckedAt
+	Threshold cns.Threshold `json:"threshold"`
+	CheckedAt time.Time     `json:"checked_at"`
+}
+
+// Evaluator evaluates chains liveness.
+type Evaluator struct {
+	Clock Clock
+	// HaltFactor is the multiple of a chain ValidBlockThresh after which a chain not producing blocks
+	// is considered halted rather than lagging; 1 means chains are never lagging.
+	HaltFactor float64
+}
+
+// NewEvaluator returns an Evaluator using clock, considering chains halted after haltFactor times their
+// ValidBlockThresh without blocks.
+func NewEvaluator(clock Clock, haltFactor float64) (Evaluator, error) {
+	if clock == nil {
+		return Evaluator{}, fmt.Errorf("nil clock")
+	}
+
+	if haltFactor < 1 {
+		return Evaluator{}, fmt.Errorf("halt factor %v is less than 1", haltFactor)
+	}
+
+	return Evaluator{Clock: clock, HaltFactor: haltFactor}, nil
+}
+
+// Evaluate returns c's liveness given its latest block time row, which may be nil if unknown.
+// SystemClock is used if e's Clock is nil.
+func (e Evaluator) Evaluate(c cns.Chain, latest *tracelistener.BlockTimeRow) Status {
+	clock := e.Clock
+	if clock == nil {
+		clock = SystemClock
+	}
+
+	now := clock.Now()
+
+	s := Status{
+		ChainName: c.ChainName,
+		Health:    HealthUnknown,
+		Threshold: c.ValidBlockThresh,
+		CheckedAt: now,
+	}
+
+	if latest == nil || latest.BlockTime.IsZero() || c.ValidBlockThresh <= 0 {
+		return s
+	}
+
+	if latest.ChainName != "" && latest.ChainName != c.ChainName {
+		return s
+	}
+
+	s.LastBlock = latest.BlockTime
+	s.Age = now.Sub(latest.BlockTime)
+
+	threshold := time.Duration(c.ValidBlockThresh)
+	haltFactor := e.HaltFactor
+	if haltFactor < 1 {
+		haltFactor = 1
+	}
+
+	switch {
+	case s.Age <= threshold:
+		s.Health = HealthLive
+	case float64(s.Age) <= float64(threshold)*haltFactor:
+		s.Health = HealthLagging
+	default:
+		s.Health = HealthHalted
+	}
+
+	return s
+}
+
+// Halt is a period during which a chain didn't produce blocks.
+type Halt struct {
+	Start time.Time `json:"start"` // time of the last block before the halt
+	End   time.Time `json:"end"`   // time the chain was first seen producing blocks again, zero if ongoing
+}
+
+// Ongoing returns true if the halt hasn't ended yet.
+func (h Halt) Ongoing() bool {
+	return h.End.IsZero()
+}
+
+// Duration returns the duration of h, up to now if it's ongoing.
+func (h Halt) Duration(now time.Time) time.Duration {
+	if h.Ongoing() {
+		return now.Sub(h.Start)
+	}
+
+	return h.End.Sub(h.Start)
+}
+
+// History is a chain's liveness statuses, sorted by CheckedAt.
+type History []Status
+
+// Add returns a copy of h with s inserted at its CheckedAt position, h is left unchanged.
+func (h History) Add(s Status) History {
+	i := sort.Search(len(h), func(i int) bool {
+		return h[i].CheckedAt.After(s.CheckedAt)
+	})
+
+	ret := make(History, len(h)+1)
+	copy(ret, h[:i])
+	ret[i] = s
+	copy(ret[i+1:], h[i:])
+
+	return ret
+}
+
+// Halts returns the halts recorded in h.
+// A halt starts at the last block seen by the first halted status, and ends with the first following
+// live or lagging status; unknown statuses neither start nor end halts.
+func (h History) Halts() []Halt {
+	var (
+		ret     []Halt
+		current *Halt
+	)
+
+	for _, s := range h {
+		switch s.Health {
+		case HealthHalted:
+			if current == nil {
+				current = &Halt{Start: s.LastBlock}
+			}
+		case HealthLive, HealthLagging:
+			if current != nil {
+				current.End = s.CheckedAt
+				ret = append(ret, *current)
+				current = nil
+			}
+		}
+	}
+
+	if current != nil {
+		ret = append(ret, *current)
+	}
+
+	return ret
+}
+
+// HaltDuration returns the total time the chain has been halted, counting ongoing halts up to now.
+func (h History) HaltDuration(now time.Time) time.Duration {
+	var total time.Duration
+	for _, halt := range h.Halts() {
+		total += halt.Duration(now)
+	}
+
+	return total
+}
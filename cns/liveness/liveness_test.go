@@ -0,0 +1,134 @@
+package liveness_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+	"github.com/emerishq/demeris-backend-models/cns/liveness"
+	"github.com/emerishq/demeris-backend-models/tracelistener"
+)
+
+var now = time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func fixedClock(t time.Time) liveness.Clock {
+	return liveness.ClockFunc(func() time.Time { return t })
+}
+
+func blockTime(chain string, t time.Time) *tracelistener.BlockTimeRow {
+	r := tracelistener.BlockTimeRow{BlockTime: t}
+	r.ChainName = chain
+	return &r
+}
+
+func TestEvaluate(t *testing.T) {
+	e, err := liveness.NewEvaluator(fixedClock(now), 3)
+	require.NoError(t, err)
+
+	hub := cns.Chain{ChainName: "cosmos-hub", ValidBlockThresh: cns.Threshold(time.Minute)}
+
+	tests := []struct {
+		name     string
+		chain    cns.Chain
+		latest   *tracelistener.BlockTimeRow
+		expected liveness.Health
+	}{
+		{"live", hub, blockTime("cosmos-hub", now.Add(-10*time.Second)), liveness.HealthLive},
+		{"live at threshold", hub, blockTime("cosmos-hub", now.Add(-time.Minute)), liveness.HealthLive},
+		{"block in the future", hub, blockTime("cosmos-hub", now.Add(time.Second)), liveness.HealthLive},
+		{"lagging", hub, blockTime("cosmos-hub", now.Add(-2*time.Minute)), liveness.HealthLagging},
+		{"lagging at halt threshold", hub, blockTime("cosmos-hub", now.Add(-3*time.Minute)), liveness.HealthLagging},
+		{"halted", hub, blockTime("cosmos-hub", now.Add(-3*time.Minute-time.Second)), liveness.HealthHalted},
+		{"no block time row", hub, nil, liveness.HealthUnknown},
+		{"zero block time", hub, blockTime("cosmos-hub", time.Time{}), liveness.HealthUnknown},
+		{"other chain row", hub, blockTime("osmosis", now), liveness.HealthUnknown},
+		{"no threshold", cns.Chain{ChainName: "cosmos-hub"}, blockTime("cosmos-hub", now), liveness.HealthUnknown},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			s := e.Evaluate(tt.chain, tt.latest)
+			require.Equal(t, tt.expected, s.Health)
+			require.Equal(t, tt.chain.ChainName, s.ChainName)
+			require.Equal(t, now, s.CheckedAt)
+		})
+	}
+
+	s := e.Evaluate(hub, blockTime("cosmos-hub", now.Add(-2*time.Minute)))
+	require.Equal(t, 2*time.Minute, s.Age)
+	require.Equal(t, now.Add(-2*time.Minute), s.LastBlock)
+}
+
+func TestNewEvaluator(t *testing.T) {
+	_, err := liveness.NewEvaluator(nil, 2)
+	require.Error(t, err)
+
+	_, err = liveness.NewEvaluator(liveness.SystemClock, 0.5)
+	require.Error(t, err)
+
+	e, err := liveness.NewEvaluator(liveness.SystemClock, 1)
+	require.NoError(t, err)
+
+	hub := cns.Chain{ChainName: "cosmos-hub", ValidBlockThresh: cns.Threshold(time.Minute)}
+	s := e.Evaluate(hub, blockTime("cosmos-hub", time.Now().Add(-61*time.Second)))
+	require.Equal(t, liveness.HealthHalted, s.Health, "chains are never lagging with a halt factor of 1")
+}
+
+func TestEvaluatorZeroValue(t *testing.T) {
+	s := liveness.Evaluator{}.Evaluate(cns.Chain{}, nil)
+	require.Equal(t, liveness.HealthUnknown, s.Health)
+	require.False(t, s.CheckedAt.IsZero(), "the system clock is used")
+
+	hub := cns.Chain{ChainName: "cosmos-hub", ValidBlockThresh: cns.Threshold(time.Minute)}
+	s = liveness.Evaluator{}.Evaluate(hub, blockTime("cosmos-hub", time.Now().Add(-10*time.Second)))
+	require.Equal(t, liveness.HealthLive, s.Health)
+}
+
+func TestHistory(t *testing.T) {
+	status := func(h liveness.Health, checkedAt, lastBlock time.Time) liveness.Status {
+		return liveness.Status{ChainName: "cosmos-hub", Health: h, CheckedAt: checkedAt, LastBlock: lastBlock}
+	}
+
+	t0 := now
+	var h liveness.History
+	h = h.Add(status(liveness.HealthLive, t0, t0))
+	h = h.Add(status(liveness.HealthHalted, t0.Add(20*time.Minute), t0.Add(5*time.Minute)))
+	h = h.Add(status(liveness.HealthLagging, t0.Add(10*time.Minute), t0.Add(5*time.Minute)))
+	h = h.Add(status(liveness.HealthUnknown, t0.Add(30*time.Minute), time.Time{}))
+	h = h.Add(status(liveness.HealthLive, t0.Add(40*time.Minute), t0.Add(39*time.Minute)))
+	h = h.Add(status(liveness.HealthHalted, t0.Add(60*time.Minute), t0.Add(45*time.Minute)))
+
+	for i := 1; i < len(h); i++ {
+		require.True(t, h[i-1].CheckedAt.Before(h[i].CheckedAt))
+	}
+
+	halts := h.Halts()
+	require.Equal(t, []liveness.Halt{
+		{Start: t0.Add(5 * time.Minute), End: t0.Add(40 * time.Minute)},
+		{Start: t0.Add(45 * time.Minute)},
+	}, halts)
+
+	require.False(t, halts[0].Ongoing())
+	require.True(t, halts[1].Ongoing())
+	require.Equal(t, 35*time.Minute, halts[0].Duration(t0.Add(2*time.Hour)))
+	require.Equal(t, 35*time.Minute+75*time.Minute, h.HaltDuration(t0.Add(2*time.Hour)))
+
+	require.Empty(t, liveness.History{}.Halts())
+}
+
+func TestHistoryAddLeavesReceiverUnchanged(t *testing.T) {
+	a := liveness.Status{ChainName: "a", CheckedAt: now.Add(time.Minute)}
+	b := liveness.Status{ChainName: "b", CheckedAt: now.Add(2 * time.Minute)}
+	c := liveness.Status{ChainName: "c", CheckedAt: now}
+
+	orig := make(liveness.History, 0, 10)
+	orig = append(orig, a, b)
+
+	h := orig.Add(c)
+	require.Equal(t, liveness.History{c, a, b}, h)
+	require.Equal(t, liveness.History{a, b}, orig)
+	require.Equal(t, a, orig[:cap(orig)][0])
+}
@@ -0,0 +1,149 @@
+package price
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+type key struct {
+	priceID  string
+	currency string
+}
+
+// MemoryProvider is a PriceProvider holding prices in memory, mainly meant for tests.
+// It is safe for concurrent use.
+type MemoryProvider struct {
+	mu      sync.RWMutex
+	prices  map[key]Point
+	candles map[key][]OHLC
+}
+
+// File is the format of the files read by LoadFile.
+type File struct {
+	Prices  []Point `json:"prices"`
+	History []OHLC  `json:"history"`
+}
+
+// NewMemoryProvider returns an empty MemoryProvider.
+func NewMemoryProvider() *MemoryProvider {
+	return &MemoryProvider{
+		prices:  map[key]Point{},
+		candles: map[key][]OHLC{},
+	}
+}
+
+// LoadFile returns a MemoryProvider holding the prices and history of the JSON File at path.
+func LoadFile(path string) (*MemoryProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var f File
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("cannot decode %s, %w", path, err)
+	}
+
+	p := NewMemoryProvider()
+	if err := p.SetPrice(f.Prices...); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if err := p.AddCandles(f.History...); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return p, nil
+}
+
+// SetPrice stores points, replacing any older price for the same PriceID and currency.
+func (m *MemoryProvider) SetPrice(points ...Point) error {
+	for _, pt := range points {
+		if err := pt.Validate(); err != nil {
+			return err
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, pt := range points {
+		k := key{pt.PriceID, pt.Currency}
+		if old, ok := m.prices[k]; ok && old.Timestamp.After(pt.Timestamp) {
+			continue
+		}
+
+		m.prices[k] = pt
+	}
+
+	return nil
+}
+
+// AddCandles stores candles, keeping each PriceID and currency history sorted by Start.
+func (m *MemoryProvider) AddCandles(candles ...OHLC) error {
+	for _, c := range candles {
+		if err := c.Validate(); err != nil {
+			return err
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	touched := map[key]struct{}{}
+	for _, c := range candles {
+		k := key{c.PriceID, c.Currency}
+		m.candles[k] = append(m.candles[k], c)
+		touched[k] = struct{}{}
+	}
+
+	for k := range touched {
+		h := m.candles[k]
+		sort.SliceStable(h, func(i, j int) bool {
+			return h[i].Start.Before(h[j].Start)
+		})
+	}
+
+	return nil
+}
+
+// Price implements the PriceProvider interface.
+func (m *MemoryProvider) Price(ctx context.Context, priceID, currency string) (Point, error) {
+	if err := ctx.Err(); err != nil {
+		return Point{}, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	pt, ok := m.prices[key{priceID, currency}]
+	if !ok {
+		return Point{}, fmt.Errorf("%w: %s/%s", ErrPriceNotFound, priceID, currency)
+	}
+
+	return pt, nil
+}
+
+// History implements the PriceProvider interface.
+func (m *MemoryProvider) History(ctx context.Context, priceID, currency string, from, to time.Time) ([]OHLC, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var ret []OHLC
+	for _, c := range m.candles[key{priceID, currency}] {
+		if !c.Start.Before(from) && c.Start.Before(to) {
+			ret = append(ret, c)
+		}
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,201 @@
+// Package price defines the price models exchanged between the price oracle and its consumers,
+// keyed by cns.Denom's PriceID.
+package price
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+	"github.com/emerishq/demeris-backend-models/tracelistener"
+)
+
+var (
+	// ErrPriceNotFound is returned when a provider holds no price for the requested PriceID and currency.
+	ErrPriceNotFound = errors.New("price not found")
+	// ErrNoPriceID is returned when valuing a balance whose denom doesn't define a PriceID.
+	ErrNoPriceID = errors.New("denom has no price id")
+	// ErrInvalidPrice is returned when a price is not a non-negative decimal number.
+	ErrInvalidPrice = errors.New("invalid price")
+)
+
+var decimalRe = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+
+// Point is the price of a PriceID expressed in a fiat currency at a given time.
+type Point struct {
+	PriceID   string    `db:"price_id" json:"price_id"`
+	Currency  string    `db:"currency" json:"currency"` // lowercase ISO 4217 code, e.g. usd
+	Price     string    `db:"price" json:"price"`       // decimal number, e.g. 12.345
+	Timestamp time.Time `db:"timestamp" json:"timestamp"`
+}
+
+// Validate returns an error if p is not a well-formed price point.
+func (p Point) Validate() error {
+	if p.PriceID == "" {
+		return errors.New("empty price id")
+	}
+
+	if p.Currency == "" {
+		return fmt.Errorf("price %s: empty currency", p.PriceID)
+	}
+
+	if err := validateDecimal(p.Price); err != nil {
+		return fmt.Errorf("price %s: %w", p.PriceID, err)
+	}
+
+	return nil
+}
+
+// OHLC is a candle summarizing the price of a PriceID over the [Start, End) interval.
+type OHLC struct {
+	PriceID  string    `db:"price_id" json:"price_id"`
+	Currency string    `db:"currency" json:"currency"`
+	Open     string    `db:"open" json:"open"`
+	High     string    `db:"high" json:"high"`
+	Low      string    `db:"low" json:"low"`
+	Close    string    `db:"close" json:"close"`
+	Start    time.Time `db:"start_time" json:"start"`
+	End      time.Time `db:"end_time" json:"end"`
+}
+
+// Validate returns an error if o is not a well-formed candle, i.e. its prices aren't decimal
+// numbers, Low and High aren't the bounds of Open and Close, or its interval is empty.
+func (o OHLC) Validate() error {
+	if o.PriceID == "" {
+		return errors.New("empty price id")
+	}
+
+	if o.Currency == "" {
+		return fmt.Errorf("candle %s: empty currency", o.PriceID)
+	}
+
+	if !o.Start.Before(o.End) {
+		return fmt.Errorf("candle %s: start %s is not before end %s", o.PriceID, o.Start, o.End)
+	}
+
+	prices := map[string]*big.Rat{}
+	for name, v := range map[string]string{"open": o.Open, "high": o.High, "low": o.Low, "close": o.Close} {
+		r, err := parseDecimal(v)
+		if err != nil {
+			return fmt.Errorf("candle %s: %s: %w", o.PriceID, name, err)
+		}
+
+		prices[name] = r
+	}
+
+	for _, name := range []string{"open", "close"} {
+		if prices[name].Cmp(prices["low"]) < 0 || prices[name].Cmp(prices["high"]) > 0 {
+			return fmt.Errorf("candle %s: %s %s outside of [%s, %s]", o.PriceID, name, prices[name].RatString(), o.Low, o.High)
+		}
+	}
+
+	return nil
+}
+
+// PriceProvider is implemented by price sources.
+type PriceProvider interface {
+	// Price returns the latest price of priceID in currency, or ErrPriceNotFound.
+	Price(ctx context.Context, priceID, currency string) (Point, error)
+	// History returns the candles of priceID in currency which start in [from, to), sorted by Start.
+	History(ctx context.Context, priceID, currency string, from, to time.Time) ([]OHLC, error)
+}
+
+// Valuation is the fiat value of a balance.
+type Valuation struct {
+	ChainName string `json:"chain_name"`
+	Address   string `json:"address"`
+	Denom     string `json:"denom"`
+	Amount    string `json:"amount"` // balance amount in display units
+	Currency  string `json:"currency"`
+	Price     Point  `json:"price"`
+	Value     string `json:"value"` // Amount times Price.Price, exact
+}
+
+// ValueBalance values b in currency, using denom's Precision to convert b's amount to display units
+// and denom's PriceID to fetch the price from p.
+func ValueBalance(ctx context.Context, p PriceProvider, denom cns.Denom, b tracelistener.BalanceRow, currency string) (Valuation, error) {
+	if b.Denom != denom.Name {
+		return Valuation{}, fmt.Errorf("balance denom %s doesn't match denom %s", b.Denom, denom.Name)
+	}
+
+	if denom.PriceID == "" {
+		return Valuation{}, fmt.Errorf("%w: %s", ErrNoPriceID, denom.Name)
+	}
+
+	amount, err := denom.ToDisplay(b.Amount)
+	if err != nil {
+		return Valuation{}, err
+	}
+
+	point, err := p.Price(ctx, denom.PriceID, currency)
+	if err != nil {
+		return Valuation{}, fmt.Errorf("cannot fetch price for %s, %w", denom.Name, err)
+	}
+
+	value, err := multiply(amount, point.Price)
+	if err != nil {
+		return Valuation{}, err
+	}
+
+	return Valuation{
+		ChainName: b.ChainName,
+		Address:   b.Address,
+		Denom:     b.Denom,
+		Amount:    amount,
+		Currency:  currency,
+		Price:     point,
+		Value:     value,
+	}, nil
+}
+
+func validateDecimal(s string) error {
+	_, err := parseDecimal(s)
+	return err
+}
+
+func parseDecimal(s string) (*big.Rat, error) {
+	if !decimalRe.MatchString(s) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidPrice, s)
+	}
+
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidPrice, s)
+	}
+
+	return r, nil
+}
+
+// multiply returns the exact product of the decimal amount and price.
+func multiply(amount, price string) (string, error) {
+	a, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return "", fmt.Errorf("%w: %q", cns.ErrInvalidAmount, amount)
+	}
+
+	p, err := parseDecimal(price)
+	if err != nil {
+		return "", err
+	}
+
+	// the product of two decimals has at most as many fractional digits as both combined.
+	s := a.Mul(a, p).FloatString(fractionalDigits(amount) + fractionalDigits(price))
+	if strings.Contains(s, ".") {
+		s = strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+	}
+
+	return s, nil
+}
+
+func fractionalDigits(s string) int {
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return len(s) - i - 1
+	}
+
+	return 0
+}
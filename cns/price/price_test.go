@@ -0,0 +1,125 @@
+package price_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+	"github.com/emerishq/demeris-backend-models/cns/price"
+	"github.com/emerishq/demeris-backend-models/tracelistener"
+)
+
+var (
+	june1 = time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	atom  = cns.Denom{Name: "uatom", Precision: 6, PriceID: "cosmos"}
+)
+
+func TestLoadFile(t *testing.T) {
+	p, err := price.LoadFile("testdata/prices.json")
+	require.NoError(t, err)
+
+	pt, err := p.Price(context.Background(), "cosmos", "usd")
+	require.NoError(t, err)
+	require.Equal(t, "10.25", pt.Price)
+
+	_, err = p.Price(context.Background(), "cosmos", "eur")
+	require.ErrorIs(t, err, price.ErrPriceNotFound)
+
+	h, err := p.History(context.Background(), "cosmos", "usd", june1, june1.Add(24*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, h, 2)
+	require.Equal(t, "10", h[0].Open)
+	require.Equal(t, "10.5", h[1].Open)
+
+	h, err = p.History(context.Background(), "cosmos", "usd", june1.Add(11*time.Hour), june1.Add(24*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, h, 1)
+
+	_, err = price.LoadFile("testdata/missing.json")
+	require.Error(t, err)
+}
+
+func TestMemoryProviderSetPrice(t *testing.T) {
+	p := price.NewMemoryProvider()
+
+	require.NoError(t, p.SetPrice(price.Point{PriceID: "cosmos", Currency: "usd", Price: "11", Timestamp: june1.Add(time.Hour)}))
+	require.NoError(t, p.SetPrice(price.Point{PriceID: "cosmos", Currency: "usd", Price: "10", Timestamp: june1}))
+
+	pt, err := p.Price(context.Background(), "cosmos", "usd")
+	require.NoError(t, err)
+	require.Equal(t, "11", pt.Price, "older prices must not replace newer ones")
+
+	require.ErrorIs(t, p.SetPrice(price.Point{PriceID: "cosmos", Currency: "usd", Price: "-1"}), price.ErrInvalidPrice)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = p.Price(ctx, "cosmos", "usd")
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestOHLCValidate(t *testing.T) {
+	valid := price.OHLC{PriceID: "cosmos", Currency: "usd", Open: "10", High: "12", Low: "9", Close: "11", Start: june1, End: june1.Add(time.Hour)}
+
+	tests := []struct {
+		name      string
+		edit      func(*price.OHLC)
+		assertion require.ErrorAssertionFunc
+	}{
+		{"valid", func(*price.OHLC) {}, require.NoError},
+		{"open above high", func(o *price.OHLC) { o.Open = "12.5" }, require.Error},
+		{"close below low", func(o *price.OHLC) { o.Close = "8.99" }, require.Error},
+		{"empty interval", func(o *price.OHLC) { o.End = o.Start }, require.Error},
+		{"invalid price", func(o *price.OHLC) { o.Low = "1e3" }, require.Error},
+		{"missing currency", func(o *price.OHLC) { o.Currency = "" }, require.Error},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			o := valid
+			tt.edit(&o)
+			tt.assertion(t, o.Validate())
+		})
+	}
+}
+
+func TestValueBalance(t *testing.T) {
+	p := price.NewMemoryProvider()
+	require.NoError(t, p.SetPrice(price.Point{PriceID: "cosmos", Currency: "usd", Price: "10.25", Timestamp: june1}))
+
+	balance := tracelistener.BalanceRow{Address: "cosmos1a", Amount: "1500001", Denom: "uatom"}
+	balance.ChainName = "cosmos-hub"
+
+	tests := []struct {
+		name      string
+		denom     cns.Denom
+		balance   tracelistener.BalanceRow
+		expected  string
+		assertion require.ErrorAssertionFunc
+	}{
+		{"exact value", atom, balance, "15.37501025", require.NoError},
+		{"zero balance", atom, tracelistener.BalanceRow{Amount: "0", Denom: "uatom"}, "0", require.NoError},
+		{"no price id", cns.Denom{Name: "uatom", Precision: 6}, balance, "", require.Error},
+		{"denom mismatch", cns.Denom{Name: "uosmo", PriceID: "osmosis"}, balance, "", require.Error},
+		{"price not found", cns.Denom{Name: "uatom", PriceID: "unknown"}, balance, "", require.Error},
+		{"invalid amount", atom, tracelistener.BalanceRow{Amount: "1.5", Denom: "uatom"}, "", require.Error},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := price.ValueBalance(context.Background(), p, tt.denom, tt.balance, "usd")
+			tt.assertion(t, err)
+			require.Equal(t, tt.expected, v.Value)
+		})
+	}
+
+	v, err := price.ValueBalance(context.Background(), p, atom, balance, "usd")
+	require.NoError(t, err)
+	require.Equal(t, "cosmos-hub", v.ChainName)
+	require.Equal(t, "1.500001", v.Amount)
+	require.Equal(t, "10.25", v.Price.Price)
+}
@@ -0,0 +1,216 @@
+package cns
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// Registry is a concurrency-safe, in-memory index of CNS chains.
+// Lookups are served from an immutable snapshot, which Reload atomically replaces.
+// Chains returned by a Registry share their slices and maps with the snapshot and must not be modified;
+// the lists of chains returned by Chains, EnabledChains and DisabledChains are copies.
+type Registry struct {
+	snapshot atomic.Value // *registrySnapshot
+}
+
+type registrySnapshot struct {
+	chains    []Chain
+	byName    map[string]int
+	byChainID map[string]int
+	byPrefix  map[string]int
+	byDenom   map[string]int
+	byPriceID map[string]int
+	byFeeAddr map[string]int
+	enabled   []Chain
+	disabled  []Chain
+}
+
+// NewRegistry returns a Registry holding chains.
+func NewRegistry(chains []Chain) (*Registry, error) {
+	r := &Registry{}
+	if err := r.Reload(chains); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Reload atomically replaces the chains held by r.
+// Chain names and chain IDs must be unique, otherwise an error is returned and r is left untouched.
+// For other keys, such as bech32 prefixes or denom names, the first chain in chains wins.
+func (r *Registry) Reload(chains []Chain) error {
+	s, err := newRegistrySnapshot(chains)
+	if err != nil {
+		return err
+	}
+
+	r.snapshot.Store(s)
+
+	return nil
+}
+
+func newRegistrySnapshot(chains []Chain) (*registrySnapshot, error) {
+	s := &registrySnapshot{
+		chains:    make([]Chain, len(chains)),
+		byName:    make(map[string]int, len(chains)),
+		byChainID: make(map[string]int, len(chains)),
+		byPrefix:  make(map[string]int, len(chains)),
+		byDenom:   map[string]int{},
+		byPriceID: map[string]int{},
+		byFeeAddr: map[string]int{},
+	}
+
+	copy(s.chains, chains)
+
+	for i, c := range s.chains {
+		if _, ok := s.byName[c.ChainName]; ok {
+			return nil, fmt.Errorf("duplicate chain name %s", c.ChainName)
+		}
+
+		s.byName[c.ChainName] = i
+
+		if c.NodeInfo.ChainID != "" {
+			if j, ok := s.byChainID[c.NodeInfo.ChainID]; ok {
+				return nil, fmt.Errorf("chains %s and %s share chain id %s", s.chains[j].ChainName, c.ChainName, c.NodeInfo.ChainID)
+			}
+
+			s.byChainID[c.NodeInfo.ChainID] = i
+		}
+
+		setFirst(s.byPrefix, c.NodeInfo.Bech32Config.MainPrefix, i)
+
+		for _, d := range c.Denoms {
+			setFirst(s.byDenom, d.Name, i)
+			setFirst(s.byPriceID, d.PriceID, i)
+		}
+
+		for _, addr := range c.DemerisAddresses {
+			setFirst(s.byFeeAddr, addr, i)
+		}
+
+		if c.Enabled {
+			s.enabled = append(s.enabled, c)
+		} else {
+			s.disabled = append(s.disabled, c)
+		}
+	}
+
+	return s, nil
+}
+
+func setFirst(m map[string]int, key string, idx int) {
+	if key == "" {
+		return
+	}
+
+	if _, ok := m[key]; !ok {
+		m[key] = idx
+	}
+}
+
+func (r *Registry) load() *registrySnapshot {
+	s, ok := r.snapshot.Load().(*registrySnapshot)
+	if !ok {
+		return &registrySnapshot{}
+	}
+
+	return s
+}
+
+func (s *registrySnapshot) lookup(m map[string]int, key string) (Chain, bool) {
+	idx, ok := m[key]
+	if !ok {
+		return Chain{}, false
+	}
+
+	return s.chains[idx], true
+}
+
+// Chains returns all the chains held by r, in load order.
+func (r *Registry) Chains() []Chain {
+	return copyChains(r.load().chains)
+}
+
+// EnabledChains returns the chains held by r which are enabled, in load order.
+func (r *Registry) EnabledChains() []Chain {
+	return copyChains(r.load().enabled)
+}
+
+// DisabledChains returns the chains held by r which are not enabled, in load order.
+func (r *Registry) DisabledChains() []Chain {
+	return copyChains(r.load().disabled)
+}
+
+func copyChains(chains []Chain) []Chain {
+	if chains == nil {
+		return nil
+	}
+
+	ret := make([]Chain, len(chains))
+	copy(ret, chains)
+
+	return ret
+}
+
+// Len returns the number of chains held by r.
+func (r *Registry) Len() int {
+	return len(r.load().chains)
+}
+
+// ByName returns the chain with the given chain name.
+func (r *Registry) ByName(name string) (Chain, bool) {
+	s := r.load()
+	return s.lookup(s.byName, name)
+}
+
+// ByChainID returns the chain whose NodeInfo.ChainID is chainID.
+func (r *Registry) ByChainID(chainID string) (Chain, bool) {
+	s := r.load()
+	return s.lookup(s.byChainID, chainID)
+}
+
+// ByBech32Prefix returns the chain whose bech32 main prefix is prefix.
+func (r *Registry) ByBech32Prefix(prefix string) (Chain, bool) {
+	s := r.load()
+	return s.lookup(s.byPrefix, prefix)
+}
+
+// ByDenom returns the chain to which the denom named name is native, along with the denom itself.
+func (r *Registry) ByDenom(name string) (Chain, Denom, bool) {
+	s := r.load()
+	c, ok := s.lookup(s.byDenom, name)
+	if !ok {
+		return Chain{}, Denom{}, false
+	}
+
+	for _, d := range c.Denoms {
+		if d.Name == name {
+			return c, d, true
+		}
+	}
+
+	return Chain{}, Denom{}, false
+}
+
+// ByPriceID returns the chain holding the native denom with the given PriceID, along with the denom itself.
+func (r *Registry) ByPriceID(priceID string) (Chain, Denom, bool) {
+	s := r.load()
+	c, ok := s.lookup(s.byPriceID, priceID)
+	if !ok {
+		return Chain{}, Denom{}, false
+	}
+
+	for _, d := range c.Denoms {
+		if d.PriceID == priceID {
+			return c, d, true
+		}
+	}
+
+	return Chain{}, Denom{}, false
+}
+
+// ByFeeAddress returns the chain which accepts fee payments on address.
+func (r *Registry) ByFeeAddress(address string) (Chain, bool) {
+	s := r.load()
+	return s.lookup(s.byFeeAddr, address)
+}
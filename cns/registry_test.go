@@ -0,0 +1,156 @@
+package cns_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+)
+
+func registryTestChains() []cns.Chain {
+	return []cns.Chain{
+		{
+			ChainName:        "cosmos-hub",
+			Enabled:          true,
+			Denoms:           cns.DenomList{{Name: "uatom", PriceID: "cosmos"}},
+			DemerisAddresses: []string{"cosmos1fee"},
+			NodeInfo: cns.NodeInfo{
+				ChainID:      "cosmoshub-4",
+				Bech32Config: cns.Bech32Config{MainPrefix: "cosmos"},
+			},
+		},
+		{
+			ChainName:        "osmosis",
+			Enabled:          false,
+			Denoms:           cns.DenomList{{Name: "uosmo", PriceID: "osmosis"}, {Name: "uion"}},
+			DemerisAddresses: []string{"osmo1fee"},
+			NodeInfo: cns.NodeInfo{
+				ChainID:      "osmosis-1",
+				Bech32Config: cns.Bech32Config{MainPrefix: "osmo"},
+			},
+		},
+	}
+}
+
+func TestRegistryLookups(t *testing.T) {
+	r, err := cns.NewRegistry(registryTestChains())
+	require.NoError(t, err)
+	require.Equal(t, 2, r.Len())
+
+	c, ok := r.ByName("osmosis")
+	require.True(t, ok)
+	require.Equal(t, "osmosis", c.ChainName)
+
+	c, ok = r.ByChainID("cosmoshub-4")
+	require.True(t, ok)
+	require.Equal(t, "cosmos-hub", c.ChainName)
+
+	c, ok = r.ByBech32Prefix("osmo")
+	require.True(t, ok)
+	require.Equal(t, "osmosis", c.ChainName)
+
+	c, d, ok := r.ByDenom("uion")
+	require.True(t, ok)
+	require.Equal(t, "osmosis", c.ChainName)
+	require.Equal(t, "uion", d.Name)
+
+	c, d, ok = r.ByPriceID("cosmos")
+	require.True(t, ok)
+	require.Equal(t, "cosmos-hub", c.ChainName)
+	require.Equal(t, "uatom", d.Name)
+
+	c, ok = r.ByFeeAddress("osmo1fee")
+	require.True(t, ok)
+	require.Equal(t, "osmosis", c.ChainName)
+
+	_, ok = r.ByName("akash")
+	require.False(t, ok)
+
+	_, _, ok = r.ByPriceID("")
+	require.False(t, ok)
+}
+
+func TestRegistryEnabledFiltering(t *testing.T) {
+	r, err := cns.NewRegistry(registryTestChains())
+	require.NoError(t, err)
+
+	require.Len(t, r.Chains(), 2)
+	require.Len(t, r.EnabledChains(), 1)
+	require.Equal(t, "cosmos-hub", r.EnabledChains()[0].ChainName)
+	require.Len(t, r.DisabledChains(), 1)
+	require.Equal(t, "osmosis", r.DisabledChains()[0].ChainName)
+}
+
+func TestRegistryChainsAreCopies(t *testing.T) {
+	r, err := cns.NewRegistry(registryTestChains())
+	require.NoError(t, err)
+
+	chains := r.Chains()
+	chains[0], chains[1] = chains[1], chains[0]
+	r.EnabledChains()[0] = cns.Chain{}
+	r.DisabledChains()[0] = cns.Chain{}
+
+	c, ok := r.ByName("cosmos-hub")
+	require.True(t, ok)
+	require.Equal(t, "cosmos-hub", c.ChainName)
+	require.Equal(t, "cosmos-hub", r.Chains()[0].ChainName)
+	require.Equal(t, "cosmos-hub", r.EnabledChains()[0].ChainName)
+	require.Equal(t, "osmosis", r.DisabledChains()[0].ChainName)
+}
+
+func TestRegistryReload(t *testing.T) {
+	r, err := cns.NewRegistry(registryTestChains())
+	require.NoError(t, err)
+
+	chains := registryTestChains()[:1]
+	require.NoError(t, r.Reload(chains))
+
+	_, ok := r.ByName("osmosis")
+	require.False(t, ok)
+
+	// invalid snapshots leave the registry untouched
+	dup := append(registryTestChains(), registryTestChains()[0])
+	require.Error(t, r.Reload(dup))
+	require.Equal(t, 1, r.Len())
+
+	sameChainID := registryTestChains()
+	sameChainID[1].NodeInfo.ChainID = "cosmoshub-4"
+	require.Error(t, r.Reload(sameChainID))
+
+	// the zero value is an empty registry
+	var empty cns.Registry
+	require.Equal(t, 0, empty.Len())
+	_, ok = empty.ByName("cosmos-hub")
+	require.False(t, ok)
+}
+
+func TestRegistryConcurrentReload(t *testing.T) {
+	r, err := cns.NewRegistry(registryTestChains())
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+
+		go func(i int) {
+			defer wg.Done()
+
+			chains := registryTestChains()
+			chains[0].DisplayName = fmt.Sprint(i)
+			assert.NoError(t, r.Reload(chains))
+		}(i)
+
+		go func() {
+			defer wg.Done()
+
+			_, ok := r.ByName("cosmos-hub")
+			assert.True(t, ok)
+		}()
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,70 @@
+package cns
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrRelayerTokenNotDefined is returned when a chain has no relayer denom.
+	ErrRelayerTokenNotDefined = errors.New("relayer token not defined")
+	// ErrMultipleRelayerTokens is returned when a chain has more than one relayer denom.
+	ErrMultipleRelayerTokens = errors.New("multiple relayer tokens defined")
+	// ErrRelayerThresholdNotDefined is returned when a relayer denom has no minimum balance threshold.
+	ErrRelayerThresholdNotDefined = errors.New("relayer token minimum balance threshold not defined")
+)
+
+// FindRelayerToken returns the relayer token for a given chain, or an error if there isn't exactly one.
+func (c Chain) FindRelayerToken() (Denom, error) {
+	var (
+		ret   Denom
+		found bool
+	)
+
+	for _, d := range c.Denoms {
+		if !d.RelayerDenom {
+			continue
+		}
+
+		if found {
+			return Denom{}, fmt.Errorf("chain %s: %w: %s, %s", c.ChainName, ErrMultipleRelayerTokens, ret.Name, d.Name)
+		}
+
+		ret = d
+		found = true
+	}
+
+	if !found {
+		return Denom{}, fmt.Errorf("chain %s: %w", c.ChainName, ErrRelayerTokenNotDefined)
+	}
+
+	return ret, nil
+}
+
+// FindRelayerTokenWithThreshold returns the relayer token for a given chain, or an error if there
+// isn't exactly one or if its MinimumThreshRelayerBalance is not set.
+// Unlike ValidateRelayerToken, disabled chains are checked as well.
+func (c Chain) FindRelayerTokenWithThreshold() (Denom, error) {
+	d, err := c.FindRelayerToken()
+	if err != nil {
+		return Denom{}, err
+	}
+
+	if d.MinimumThreshRelayerBalance == nil {
+		return Denom{}, fmt.Errorf("chain %s: %w for %s", c.ChainName, ErrRelayerThresholdNotDefined, d.Name)
+	}
+
+	return d, nil
+}
+
+// ValidateRelayerToken returns an error if c is enabled and doesn't define exactly one relayer
+// denom with MinimumThreshRelayerBalance set.
+// Disabled chains are not validated.
+func (c Chain) ValidateRelayerToken() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	_, err := c.FindRelayerTokenWithThreshold()
+	return err
+}
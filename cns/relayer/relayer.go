@@ -0,0 +1,175 @@
+// Package relayer evaluates relayer account balances against the minimum thresholds defined in CNS.
+package relayer
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+	"github.com/emerishq/demeris-backend-models/tracelistener"
+)
+
+// Status is the outcome of a relayer balance evaluation.
+type Status string
+
+const (
+	// StatusOK means the balance is above the threshold and its warning margin.
+	StatusOK Status = "ok"
+	// StatusWarning means the balance is above the threshold, but within its warning margin.
+	StatusWarning Status = "warning"
+	// StatusBelowThreshold means the balance is below the threshold.
+	StatusBelowThreshold Status = "below_threshold"
+	// StatusMissingBalance means no readable balance of the relayer token was found.
+	StatusMissingBalance Status = "missing_balance"
+	// StatusMisconfigured means the chain doesn't define a single relayer token with a threshold.
+	StatusMisconfigured Status = "misconfigured"
+)
+
+// Result is the evaluation of a chain's relayer balance.
+type Result struct {
+	ChainName string `json:"chain_name"`
+	Address   string `json:"address,omitempty"`
+	Denom     string `json:"denom,omitempty"`
+	Balance   string `json:"balance,omitempty"`
+	Threshold *int64 `json:"threshold,omitempty"`
+	Status    Status `json:"status"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Results is a list of Result, sorted by chain name.
+type Results []Result
+
+// Filter returns the results having one of statuses.
+func (r Results) Filter(statuses ...Status) Results {
+	var ret Results
+	for _, res := range r {
+		for _, s := range statuses {
+			if res.Status == s {
+				ret = append(ret, res)
+				break
+			}
+		}
+	}
+
+	return ret
+}
+
+// Unhealthy returns the results which aren't StatusOK.
+func (r Results) Unhealthy() Results {
+	return r.Filter(StatusWarning, StatusBelowThreshold, StatusMissingBalance, StatusMisconfigured)
+}
+
+// Evaluator evaluates relayer balances.
+type Evaluator struct {
+	// WarningMargin is the fraction of the threshold above it under which a balance raises a
+	// warning, e.g. 0.2 warns for balances lower than 120% of the threshold.
+	WarningMargin float64
+}
+
+// NewEvaluator returns an Evaluator warning for balances within margin of their threshold.
+func NewEvaluator(margin float64) (Evaluator, error) {
+	if margin < 0 {
+		return Evaluator{}, fmt.Errorf("negative warning margin %v", margin)
+	}
+
+	return Evaluator{WarningMargin: margin}, nil
+}
+
+// Evaluate returns the relayer balance status of each enabled chain.
+// balances are the relayer accounts balances, matched to chains by ChainName and relayer token denom;
+// if a chain has more than one matching balance, the lowest one is evaluated.
+func (e Evaluator) Evaluate(chains []cns.Chain, balances []tracelistener.BalanceRow) Results {
+	byChain := map[string][]tracelistener.BalanceRow{}
+	for _, b := range balances {
+		byChain[b.ChainName] = append(byChain[b.ChainName], b)
+	}
+
+	var ret Results
+	for _, c := range chains {
+		if !c.Enabled {
+			continue
+		}
+
+		ret = append(ret, e.EvaluateChain(c, byChain[c.ChainName]))
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].ChainName < ret[j].ChainName
+	})
+
+	return ret
+}
+
+// EvaluateChain returns c's relayer balance status out of balances, whether c is enabled or not.
+// Balances of other chains or denoms, and balances with an invalid amount, are ignored.
+func (e Evaluator) EvaluateChain(c cns.Chain, balances []tracelistener.BalanceRow) Result {
+	res := Result{ChainName: c.ChainName}
+
+	d, err := c.FindRelayerTokenWithThreshold()
+	if err != nil {
+		res.Status = StatusMisconfigured
+		res.Error = err.Error()
+		return res
+	}
+
+	res.Denom = d.Name
+	res.Threshold = d.MinimumThreshRelayerBalance
+
+	var (
+		lowest  *big.Int
+		invalid string
+	)
+	for _, b := range balances {
+		if b.ChainName != c.ChainName || b.Denom != d.Name {
+			continue
+		}
+
+		amount, ok := new(big.Int).SetString(b.Amount, 10)
+		if !ok {
+			if invalid == "" {
+				invalid = fmt.Sprintf("invalid balance amount %q for %s", b.Amount, b.Address)
+			}
+
+			continue
+		}
+
+		if lowest == nil || amount.Cmp(lowest) < 0 {
+			lowest = amount
+			res.Address = b.Address
+			res.Balance = b.Amount
+		}
+	}
+
+	if lowest == nil {
+		res.Status = StatusMissingBalance
+		res.Error = invalid
+		return res
+	}
+
+	threshold := new(big.Rat).SetInt64(*d.MinimumThreshRelayerBalance)
+	balance := new(big.Rat).SetInt(lowest)
+
+	switch {
+	case balance.Cmp(threshold) < 0:
+		res.Status = StatusBelowThreshold
+	case balance.Cmp(e.warningLevel(threshold)) < 0:
+		res.Status = StatusWarning
+	default:
+		res.Status = StatusOK
+	}
+
+	return res
+}
+
+// warningLevel returns threshold * (1 + WarningMargin).
+func (e Evaluator) warningLevel(threshold *big.Rat) *big.Rat {
+	margin, err := cns.RatFromFloat(e.WarningMargin)
+	if err != nil || margin.Sign() < 0 {
+		return threshold
+	}
+
+	margin.Add(margin, big.NewRat(1, 1))
+
+	return margin.Mul(margin, threshold)
+}
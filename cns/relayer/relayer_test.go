@@ -0,0 +1,113 @@
+package relayer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+	"github.com/emerishq/demeris-backend-models/cns/relayer"
+	"github.com/emerishq/demeris-backend-models/tracelistener"
+)
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func relayerChain(name string, threshold *int64) cns.Chain {
+	return cns.Chain{
+		ChainName: name,
+		Enabled:   true,
+		Denoms: cns.DenomList{
+			{Name: "u" + name, RelayerDenom: true, MinimumThreshRelayerBalance: threshold},
+			{Name: "other"},
+		},
+	}
+}
+
+func disabledRelayerChain(name string, threshold *int64) cns.Chain {
+	c := relayerChain(name, threshold)
+	c.Enabled = false
+	return c
+}
+
+func balance(chain, denom, amount string) tracelistener.BalanceRow {
+	b := tracelistener.BalanceRow{Address: chain + "1relayer", Denom: denom, Amount: amount}
+	b.ChainName = chain
+	return b
+}
+
+func TestEvaluateChain(t *testing.T) {
+	e, err := relayer.NewEvaluator(0.2)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		chain    cns.Chain
+		balances []tracelistener.BalanceRow
+		expected relayer.Status
+	}{
+		{"ok", relayerChain("foo", int64Ptr(1000)), []tracelistener.BalanceRow{balance("foo", "ufoo", "1200")}, relayer.StatusOK},
+		{"warning", relayerChain("foo", int64Ptr(1000)), []tracelistener.BalanceRow{balance("foo", "ufoo", "1199")}, relayer.StatusWarning},
+		{"at threshold", relayerChain("foo", int64Ptr(1000)), []tracelistener.BalanceRow{balance("foo", "ufoo", "1000")}, relayer.StatusWarning},
+		{"below threshold", relayerChain("foo", int64Ptr(1000)), []tracelistener.BalanceRow{balance("foo", "ufoo", "999")}, relayer.StatusBelowThreshold},
+		{"larger than int64", relayerChain("foo", int64Ptr(1000)), []tracelistener.BalanceRow{balance("foo", "ufoo", "100000000000000000000")}, relayer.StatusOK},
+		{"lowest balance evaluated", relayerChain("foo", int64Ptr(1000)), []tracelistener.BalanceRow{balance("foo", "ufoo", "5000"), balance("foo", "ufoo", "10")}, relayer.StatusBelowThreshold},
+		{"missing balance", relayerChain("foo", int64Ptr(1000)), nil, relayer.StatusMissingBalance},
+		{"other denom only", relayerChain("foo", int64Ptr(1000)), []tracelistener.BalanceRow{balance("foo", "other", "5000")}, relayer.StatusMissingBalance},
+		{"other chain only", relayerChain("foo", int64Ptr(1000)), []tracelistener.BalanceRow{balance("bar", "ufoo", "5000")}, relayer.StatusMissingBalance},
+		{"invalid amount", relayerChain("foo", int64Ptr(1000)), []tracelistener.BalanceRow{balance("foo", "ufoo", "1.5")}, relayer.StatusMissingBalance},
+		{"invalid amount ignored", relayerChain("foo", int64Ptr(1000)), []tracelistener.BalanceRow{balance("foo", "ufoo", "1.5"), balance("foo", "ufoo", "5000")}, relayer.StatusOK},
+		{"invalid amount ignored, any order", relayerChain("foo", int64Ptr(1000)), []tracelistener.BalanceRow{balance("foo", "ufoo", "5000"), balance("foo", "ufoo", "1.5")}, relayer.StatusOK},
+		{"nil threshold on disabled chain", disabledRelayerChain("foo", nil), []tracelistener.BalanceRow{balance("foo", "ufoo", "5000")}, relayer.StatusMisconfigured},
+		{"nil threshold", relayerChain("foo", nil), []tracelistener.BalanceRow{balance("foo", "ufoo", "5000")}, relayer.StatusMisconfigured},
+		{"no relayer token", cns.Chain{ChainName: "foo", Enabled: true}, []tracelistener.BalanceRow{balance("foo", "ufoo", "5000")}, relayer.StatusMisconfigured},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			res := e.EvaluateChain(tt.chain, tt.balances)
+			require.Equal(t, tt.expected, res.Status)
+			require.Equal(t, tt.chain.ChainName, res.ChainName)
+			if tt.expected == relayer.StatusMisconfigured {
+				_, err := tt.chain.FindRelayerTokenWithThreshold()
+				require.EqualError(t, err, res.Error)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	disabled := relayerChain("baz", int64Ptr(1000))
+	disabled.Enabled = false
+
+	chains := []cns.Chain{
+		relayerChain("foo", int64Ptr(1000)),
+		relayerChain("bar", int64Ptr(1000)),
+		relayerChain("qux", nil),
+		disabled,
+	}
+
+	balances := []tracelistener.BalanceRow{
+		balance("foo", "ufoo", "10"),
+		balance("bar", "ubar", "2000"),
+		balance("baz", "ubaz", "0"),
+	}
+
+	res := relayer.Evaluator{}.Evaluate(chains, balances)
+	require.Len(t, res, 3)
+
+	require.Equal(t, relayer.Result{ChainName: "bar", Address: "bar1relayer", Denom: "ubar", Balance: "2000", Threshold: int64Ptr(1000), Status: relayer.StatusOK}, res[0])
+	require.Equal(t, "foo", res[1].ChainName)
+	require.Equal(t, relayer.StatusBelowThreshold, res[1].Status)
+	require.Equal(t, "qux", res[2].ChainName)
+	require.Equal(t, relayer.StatusMisconfigured, res[2].Status)
+
+	unhealthy := res.Unhealthy()
+	require.Len(t, unhealthy, 2)
+	require.Equal(t, "foo", unhealthy[0].ChainName)
+
+	_, err := relayer.NewEvaluator(-1)
+	require.Error(t, err)
+}
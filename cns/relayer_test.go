@@ -0,0 +1,117 @@
+package cns_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+)
+
+func TestChainFindRelayerToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		denoms   cns.DenomList
+		expected cns.Denom
+		err      error
+	}{
+		{
+			"single relayer token",
+			cns.DenomList{{Name: "stake"}, {Name: "uatom", RelayerDenom: true}},
+			cns.Denom{Name: "uatom", RelayerDenom: true},
+			nil,
+		},
+		{
+			"no relayer token",
+			cns.DenomList{{Name: "uatom"}},
+			cns.Denom{},
+			cns.ErrRelayerTokenNotDefined,
+		},
+		{
+			"multiple relayer tokens",
+			cns.DenomList{{Name: "stake", RelayerDenom: true}, {Name: "uatom", RelayerDenom: true}},
+			cns.Denom{},
+			cns.ErrMultipleRelayerTokens,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := cns.Chain{ChainName: "foo", Denoms: tt.denoms}.FindRelayerToken()
+			require.ErrorIs(t, err, tt.err)
+			require.Equal(t, tt.expected, d)
+		})
+	}
+}
+
+func TestChainValidateRelayerToken(t *testing.T) {
+	thresh := int64(1000)
+
+	tests := []struct {
+		name    string
+		enabled bool
+		denoms  cns.DenomList
+		err     error
+	}{
+		{
+			"valid",
+			true,
+			cns.DenomList{{Name: "uatom", RelayerDenom: true, MinimumThreshRelayerBalance: &thresh}},
+			nil,
+		},
+		{
+			"missing threshold",
+			true,
+			cns.DenomList{{Name: "uatom", RelayerDenom: true}},
+			cns.ErrRelayerThresholdNotDefined,
+		},
+		{
+			"missing relayer token",
+			true,
+			cns.DenomList{{Name: "uatom"}},
+			cns.ErrRelayerTokenNotDefined,
+		},
+		{
+			"multiple relayer tokens",
+			true,
+			cns.DenomList{
+				{Name: "uatom", RelayerDenom: true, MinimumThreshRelayerBalance: &thresh},
+				{Name: "stake", RelayerDenom: true, MinimumThreshRelayerBalance: &thresh},
+			},
+			cns.ErrMultipleRelayerTokens,
+		},
+		{
+			"disabled chains are not validated",
+			false,
+			cns.DenomList{{Name: "uatom"}},
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := cns.Chain{ChainName: "foo", Enabled: tt.enabled, Denoms: tt.denoms}.ValidateRelayerToken()
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestChainFindRelayerTokenWithThreshold(t *testing.T) {
+	thresh := int64(1000)
+
+	d, err := cns.Chain{
+		ChainName: "foo",
+		Denoms:    cns.DenomList{{Name: "uatom", RelayerDenom: true, MinimumThreshRelayerBalance: &thresh}},
+	}.FindRelayerTokenWithThreshold()
+	require.NoError(t, err)
+	require.Equal(t, "uatom", d.Name)
+
+	// disabled chains are checked as well
+	_, err = cns.Chain{
+		ChainName: "foo",
+		Denoms:    cns.DenomList{{Name: "uatom", RelayerDenom: true}},
+	}.FindRelayerTokenWithThreshold()
+	require.ErrorIs(t, err, cns.ErrRelayerThresholdNotDefined)
+}
@@ -0,0 +1,183 @@
+package cns
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ErrRevisionNotFound is returned when a revision is not part of a ChainHistory.
+var ErrRevisionNotFound = errors.New("revision not found")
+
+// ChainRevision represents a chain revision log row on the database.
+// Each revision holds the full chain snapshot, along with the changes from the previous revision.
+type ChainRevision struct {
+	ID        uint64       `db:"id" json:"-"`
+	ChainName string       `db:"chain_name" json:"chain_name"`
+	Revision  uint64       `db:"revision" json:"revision"`   // revision number, starting from 1
+	Author    string       `db:"author" json:"author"`       // who made the change
+	Timestamp time.Time    `db:"timestamp" json:"timestamp"` // when the change was made
+	Reason    string       `db:"reason" json:"reason"`       // why the change was made
+	Snapshot  JSONB[Chain] `db:"snapshot" json:"snapshot"`   // the chain as of this revision
+	Changes   Changelog    `db:"changes" json:"changes"`     // changes from the previous revision
+	Rollback  *uint64      `db:"rollback" json:"rollback"`   // revision restored by this revision, if any
+}
+
+// Chain returns the chain as of r.
+func (r ChainRevision) Chain() Chain {
+	return r.Snapshot.Data
+}
+
+// Scan is the sql.Scanner implementation for Changelog.
+func (cl *Changelog) Scan(value interface{}) error {
+	return scanJSONB(value, cl)
+}
+
+// Value is the driver.Valuer implementation for Changelog.
+func (cl Changelog) Value() (driver.Value, error) {
+	return NewJSONB(cl).Value()
+}
+
+// Filter returns the changes of cl whose path starts with path.
+func (cl Changelog) Filter(path ...string) Changelog {
+	var ret Changelog
+	for _, c := range cl {
+		if hasPathPrefix(c.Path, path) {
+			ret = append(ret, c)
+		}
+	}
+
+	return ret
+}
+
+func hasPathPrefix(path, prefix []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+
+	for i := range prefix {
+		if path[i] != prefix[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ChainHistory is the ordered list of revisions of a single chain.
+type ChainHistory []ChainRevision
+
+// NewChainHistory returns a ChainHistory from revisions, sorted by revision number.
+// Revisions must belong to the same chain and be numbered contiguously starting from 1.
+func NewChainHistory(revisions []ChainRevision) (ChainHistory, error) {
+	h := make(ChainHistory, len(revisions))
+	copy(h, revisions)
+
+	sort.Slice(h, func(i, j int) bool {
+		return h[i].Revision < h[j].Revision
+	})
+
+	for i, r := range h {
+		if r.ChainName != h[0].ChainName {
+			return nil, fmt.Errorf("revision %d belongs to chain %s, not %s", r.Revision, r.ChainName, h[0].ChainName)
+		}
+
+		if r.Revision != uint64(i+1) {
+			return nil, fmt.Errorf("chain %s history is not contiguous, expected revision %d, got %d", r.ChainName, i+1, r.Revision)
+		}
+	}
+
+	return h, nil
+}
+
+// Latest returns the latest revision of h.
+func (h ChainHistory) Latest() (ChainRevision, bool) {
+	if len(h) == 0 {
+		return ChainRevision{}, false
+	}
+
+	return h[len(h)-1], true
+}
+
+// Revision returns the revision numbered n.
+func (h ChainHistory) Revision(n uint64) (ChainRevision, error) {
+	for _, r := range h {
+		if r.Revision == n {
+			return r, nil
+		}
+	}
+
+	return ChainRevision{}, fmt.Errorf("%w: %d", ErrRevisionNotFound, n)
+}
+
+// At returns the chain as of revision n.
+func (h ChainHistory) At(n uint64) (Chain, error) {
+	r, err := h.Revision(n)
+	if err != nil {
+		return Chain{}, err
+	}
+
+	return r.Chain(), nil
+}
+
+// Next returns the revision recording c as the new state of the chain, to be appended to h.
+// The changes are computed against the latest revision, or against an empty Chain if h is empty.
+func (h ChainHistory) Next(c Chain, author, reason string, at time.Time) (ChainRevision, error) {
+	var prev Chain
+	next := uint64(1)
+	if latest, ok := h.Latest(); ok {
+		if latest.ChainName != c.ChainName {
+			return ChainRevision{}, fmt.Errorf("cannot record chain %s in the history of chain %s", c.ChainName, latest.ChainName)
+		}
+
+		prev = latest.Chain()
+		next = latest.Revision + 1
+	}
+
+	return ChainRevision{
+		ChainName: c.ChainName,
+		Revision:  next,
+		Author:    author,
+		Timestamp: at,
+		Reason:    reason,
+		Snapshot:  NewJSONB(c),
+		Changes:   Diff(prev, c),
+	}, nil
+}
+
+// Rollback returns the revision restoring the chain as of revision n, to be appended to h.
+// Rolling back never removes revisions, so that the history is preserved.
+func (h ChainHistory) Rollback(n uint64, author, reason string, at time.Time) (ChainRevision, error) {
+	c, err := h.At(n)
+	if err != nil {
+		return ChainRevision{}, err
+	}
+
+	r, err := h.Next(c, author, reason, at)
+	if err != nil {
+		return ChainRevision{}, err
+	}
+
+	r.Rollback = &n
+
+	return r, nil
+}
+
+// FieldHistory returns the revisions of h which changed the field at path, each with its
+// Changes filtered to path, e.g. FieldHistory("demeris_addresses") for fee address changes.
+func (h ChainHistory) FieldHistory(path ...string) ChainHistory {
+	var ret ChainHistory
+	for _, r := range h {
+		changes := r.Changes.Filter(path...)
+		if len(changes) == 0 {
+			continue
+		}
+
+		r.Changes = changes
+		ret = append(ret, r)
+	}
+
+	return ret
+}
@@ -0,0 +1,124 @@
+package cns_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+)
+
+func revisionTestHistory(t *testing.T) cns.ChainHistory {
+	t.Helper()
+
+	at := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	var h cns.ChainHistory
+
+	c := cns.Chain{ChainName: "cosmos-hub", DemerisAddresses: []string{"cosmos1a"}, CosmosSDKVersion: "v0.44.0"}
+	r, err := h.Next(c, "alice", "initial import", at)
+	require.NoError(t, err)
+	h = append(h, r)
+
+	c.CosmosSDKVersion = "v0.45.0"
+	r, err = h.Next(c, "bob", "gaia v7 upgrade", at.Add(time.Hour))
+	require.NoError(t, err)
+	h = append(h, r)
+
+	c.DemerisAddresses = []string{"cosmos1b"}
+	r, err = h.Next(c, "mallory", "rotate fee address", at.Add(2*time.Hour))
+	require.NoError(t, err)
+	h = append(h, r)
+
+	return h
+}
+
+func TestChainHistoryNext(t *testing.T) {
+	h := revisionTestHistory(t)
+
+	require.Len(t, h, 3)
+	require.Equal(t, uint64(1), h[0].Revision)
+	require.Equal(t, uint64(3), h[2].Revision)
+	require.Equal(t, "mallory", h[2].Author)
+
+	require.Equal(t, cns.Changelog{
+		{Type: cns.ChangeUpdated, Path: []string{"cosmos_sdk_version"}, From: "v0.44.0", To: "v0.45.0"},
+	}, h[1].Changes)
+
+	_, err := h.Next(cns.Chain{ChainName: "osmosis"}, "alice", "wrong chain", time.Now())
+	require.Error(t, err)
+}
+
+func TestChainHistoryAt(t *testing.T) {
+	h := revisionTestHistory(t)
+
+	c, err := h.At(2)
+	require.NoError(t, err)
+	require.Equal(t, "v0.45.0", c.CosmosSDKVersion)
+	require.Equal(t, []string{"cosmos1a"}, []string(c.DemerisAddresses))
+
+	_, err = h.At(42)
+	require.ErrorIs(t, err, cns.ErrRevisionNotFound)
+}
+
+func TestChainHistoryRollback(t *testing.T) {
+	h := revisionTestHistory(t)
+
+	r, err := h.Rollback(2, "alice", "revert unauthorized fee address change", time.Now())
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), r.Revision)
+	require.Equal(t, uint64(2), *r.Rollback)
+	require.Equal(t, []string{"cosmos1a"}, []string(r.Chain().DemerisAddresses))
+	require.Equal(t, []string{"demeris_addresses.cosmos1a", "demeris_addresses.cosmos1b"}, r.Changes.Paths())
+
+	_, err = h.Rollback(42, "alice", "", time.Now())
+	require.ErrorIs(t, err, cns.ErrRevisionNotFound)
+}
+
+func TestChainHistoryFieldHistory(t *testing.T) {
+	h := revisionTestHistory(t)
+
+	fees := h.FieldHistory("demeris_addresses")
+	require.Len(t, fees, 2)
+	require.Equal(t, "alice", fees[0].Author)
+	require.Equal(t, "mallory", fees[1].Author)
+	require.Equal(t, cns.Changelog{
+		{Type: cns.ChangeRemoved, Path: []string{"demeris_addresses", "cosmos1a"}, From: "cosmos1a"},
+		{Type: cns.ChangeAdded, Path: []string{"demeris_addresses", "cosmos1b"}, To: "cosmos1b"},
+	}, fees[1].Changes)
+}
+
+func TestNewChainHistory(t *testing.T) {
+	h := revisionTestHistory(t)
+
+	sorted, err := cns.NewChainHistory([]cns.ChainRevision{h[2], h[0], h[1]})
+	require.NoError(t, err)
+	require.Equal(t, h, sorted)
+
+	_, err = cns.NewChainHistory([]cns.ChainRevision{h[0], h[2]})
+	require.Error(t, err)
+
+	other := h[1]
+	other.ChainName = "osmosis"
+	_, err = cns.NewChainHistory([]cns.ChainRevision{h[0], other})
+	require.Error(t, err)
+}
+
+func TestChainRevisionColumns(t *testing.T) {
+	h := revisionTestHistory(t)
+
+	v, err := h[2].Changes.Value()
+	require.NoError(t, err)
+
+	var cl cns.Changelog
+	require.NoError(t, cl.Scan(v))
+	require.Equal(t, h[2].Changes, cl)
+
+	v, err = h[2].Snapshot.Value()
+	require.NoError(t, err)
+
+	var snapshot cns.JSONB[cns.Chain]
+	require.NoError(t, snapshot.Scan(v))
+	require.Equal(t, h[2].Chain().DemerisAddresses, snapshot.Data.DemerisAddresses)
+}
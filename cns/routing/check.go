@@ -0,0 +1,161 @@
+package routing
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+	"github.com/emerishq/demeris-backend-models/tracelistener"
+)
+
+// IssueKind identifies the kind of inconsistency found by CheckPrimaryChannels.
+type IssueKind string
+
+const (
+	// IssueUnknownChain marks a primary channel pointing to a chain not in CNS.
+	IssueUnknownChain IssueKind = "unknown_chain"
+	// IssueMissingCounterparty marks a primary channel whose counterparty chain has no primary channel back.
+	IssueMissingCounterparty IssueKind = "missing_counterparty"
+	// IssueAsymmetric marks a primary channel whose counterparty channel is not the counterparty chain's primary channel back.
+	IssueAsymmetric IssueKind = "asymmetric"
+	// IssueWrongCounterpartyChain marks a primary channel connected to a chain other than the one it is mapped to.
+	IssueWrongCounterpartyChain IssueKind = "wrong_counterparty_chain"
+	// IssueChannelNotFound marks a primary channel not found in the channel data.
+	IssueChannelNotFound IssueKind = "channel_not_found"
+	// IssueChannelNotOpen marks a primary channel which is not in OPEN state.
+	IssueChannelNotOpen IssueKind = "channel_not_open"
+)
+
+// Issue represents an inconsistency of a primary channel mapping.
+type Issue struct {
+	Kind         IssueKind `json:"kind"`
+	Chain        string    `json:"chain"`
+	Counterparty string    `json:"counterparty"`
+	Channel      string    `json:"channel"`
+	Message      string    `json:"message"`
+}
+
+// String implements the fmt.Stringer interface.
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: %s -> %s (%s): %s", i.Kind, i.Chain, i.Counterparty, i.Channel, i.Message)
+}
+
+type channelKey struct {
+	chain   string
+	channel string
+}
+
+// CheckPrimaryChannels reports inconsistencies of chains' PrimaryChannel mappings: references to
+// unknown chains, mappings which are not symmetric, and channels not in OPEN state.
+// infos and channels are used to resolve each channel's counterparty and state, and can be partial:
+// channels missing from both are reported with IssueChannelNotFound.
+// Issues are sorted by chain, counterparty and kind.
+func CheckPrimaryChannels(chains []cns.Chain, infos cns.IbcChannelsInfo, channels []tracelistener.IBCChannelRow) []Issue {
+	known := make(map[string]cns.Chain, len(chains))
+	for _, c := range chains {
+		known[c.ChainName] = c
+	}
+
+	byInfo := map[channelKey]cns.IbcChannelInfo{}
+	for _, info := range infos {
+		byInfo[channelKey{info.ChainAName, info.ChainAChannelID}] = info
+		byInfo[channelKey{info.ChainBName, info.ChainBChannelID}] = cns.IbcChannelInfo{
+			ChainAName:             info.ChainBName,
+			ChainAChannelID:        info.ChainBChannelID,
+			ChainACounterChannelID: info.ChainBCounterChannelID,
+			ChainAChainID:          info.ChainBChainID,
+			ChainBName:             info.ChainAName,
+			ChainBChannelID:        info.ChainAChannelID,
+			ChainBCounterChannelID: info.ChainACounterChannelID,
+			ChainBChainID:          info.ChainAChainID,
+		}
+	}
+
+	byRow := map[channelKey]tracelistener.IBCChannelRow{}
+	for _, row := range channels {
+		byRow[channelKey{row.ChainName, row.ChannelID}] = row
+	}
+
+	var issues []Issue
+	for _, c := range chains {
+		for _, counterparty := range sortedKeys(c.PrimaryChannel) {
+			channel := c.PrimaryChannel[counterparty]
+			issue := func(kind IssueKind, format string, args ...interface{}) {
+				issues = append(issues, Issue{
+					Kind:         kind,
+					Chain:        c.ChainName,
+					Counterparty: counterparty,
+					Channel:      channel,
+					Message:      fmt.Sprintf(format, args...),
+				})
+			}
+
+			cp, ok := known[counterparty]
+			if !ok {
+				issue(IssueUnknownChain, "chain %s is not defined", counterparty)
+				continue
+			}
+
+			key := channelKey{c.ChainName, channel}
+			info, infoFound := byInfo[key]
+			row, rowFound := byRow[key]
+
+			if !infoFound && !rowFound {
+				issue(IssueChannelNotFound, "channel %s not found on chain %s", channel, c.ChainName)
+			}
+
+			if infoFound && info.ChainBName != "" && info.ChainBName != counterparty {
+				issue(IssueWrongCounterpartyChain, "channel %s is connected to chain %s", channel, info.ChainBName)
+			}
+
+			if rowFound && row.State != tracelistener.ChannelStateOpen {
+				issue(IssueChannelNotOpen, "channel %s is in state %d", channel, row.State)
+			}
+
+			back, ok := cp.PrimaryChannel[c.ChainName]
+			if !ok {
+				issue(IssueMissingCounterparty, "chain %s has no primary channel for %s", counterparty, c.ChainName)
+				continue
+			}
+
+			counterChannel := ""
+			switch {
+			case rowFound && row.CounterChannelID != "":
+				counterChannel = row.CounterChannelID
+			case infoFound:
+				counterChannel = info.ChainACounterChannelID
+			}
+
+			if counterChannel != "" && counterChannel != back {
+				issue(IssueAsymmetric, "counterparty of channel %s is %s, but chain %s primary channel for %s is %s",
+					channel, counterChannel, counterparty, c.ChainName, back)
+			}
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		if a.Chain != b.Chain {
+			return a.Chain < b.Chain
+		}
+
+		if a.Counterparty != b.Counterparty {
+			return a.Counterparty < b.Counterparty
+		}
+
+		return a.Kind < b.Kind
+	})
+
+	return issues
+}
+
+func sortedKeys(m map[string]string) []string {
+	ret := make([]string, 0, len(m))
+	for k := range m {
+		ret = append(ret, k)
+	}
+
+	sort.Strings(ret)
+
+	return ret
+}
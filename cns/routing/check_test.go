@@ -0,0 +1,123 @@
+package routing_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+	"github.com/emerishq/demeris-backend-models/cns/routing"
+	"github.com/emerishq/demeris-backend-models/tracelistener"
+)
+
+func channelRow(chain, channel, counterChannel string, state int32) tracelistener.IBCChannelRow {
+	return tracelistener.IBCChannelRow{
+		TracelistenerDatabaseRow: tracelistener.TracelistenerDatabaseRow{ChainName: chain},
+		ChannelID:                channel,
+		CounterChannelID:         counterChannel,
+		Port:                     "transfer",
+		State:                    state,
+	}
+}
+
+func TestCheckPrimaryChannels(t *testing.T) {
+	chains := []cns.Chain{
+		{
+			ChainName:      "cosmos-hub",
+			PrimaryChannel: cns.DbStringMap{"osmosis": "channel-141", "akash": "channel-184", "juno": "channel-207"},
+		},
+		{
+			ChainName:      "osmosis",
+			PrimaryChannel: cns.DbStringMap{"cosmos-hub": "channel-0", "akash": "channel-1"},
+		},
+		{
+			ChainName:      "akash",
+			PrimaryChannel: cns.DbStringMap{"cosmos-hub": "channel-17", "osmosis": "channel-9"},
+		},
+	}
+
+	infos := cns.IbcChannelsInfo{
+		{
+			ChainAName:             "cosmos-hub",
+			ChainAChannelID:        "channel-141",
+			ChainACounterChannelID: "channel-0",
+			ChainBName:             "osmosis",
+			ChainBChannelID:        "channel-0",
+			ChainBCounterChannelID: "channel-141",
+		},
+		{
+			// wrongly mapped, channel-1 on osmosis goes to cosmos-hub
+			ChainAName:             "osmosis",
+			ChainAChannelID:        "channel-1",
+			ChainACounterChannelID: "channel-190",
+			ChainBName:             "cosmos-hub",
+			ChainBChannelID:        "channel-190",
+			ChainBCounterChannelID: "channel-1",
+		},
+	}
+
+	channels := []tracelistener.IBCChannelRow{
+		channelRow("cosmos-hub", "channel-184", "channel-18", tracelistener.ChannelStateOpen),
+		channelRow("akash", "channel-17", "channel-184", tracelistener.ChannelStateClosed),
+		channelRow("akash", "channel-9", "channel-1", tracelistener.ChannelStateOpen),
+	}
+
+	require.Equal(t, []routing.Issue{
+		{
+			Kind:         routing.IssueChannelNotOpen,
+			Chain:        "akash",
+			Counterparty: "cosmos-hub",
+			Channel:      "channel-17",
+			Message:      "channel channel-17 is in state 4",
+		},
+		{
+			Kind:         routing.IssueAsymmetric,
+			Chain:        "cosmos-hub",
+			Counterparty: "akash",
+			Channel:      "channel-184",
+			Message:      "counterparty of channel channel-184 is channel-18, but chain akash primary channel for cosmos-hub is channel-17",
+		},
+		{
+			Kind:         routing.IssueUnknownChain,
+			Chain:        "cosmos-hub",
+			Counterparty: "juno",
+			Channel:      "channel-207",
+			Message:      "chain juno is not defined",
+		},
+		{
+			Kind:         routing.IssueAsymmetric,
+			Chain:        "osmosis",
+			Counterparty: "akash",
+			Channel:      "channel-1",
+			Message:      "counterparty of channel channel-1 is channel-190, but chain akash primary channel for osmosis is channel-9",
+		},
+		{
+			Kind:         routing.IssueWrongCounterpartyChain,
+			Chain:        "osmosis",
+			Counterparty: "akash",
+			Channel:      "channel-1",
+			Message:      "channel channel-1 is connected to chain cosmos-hub",
+		},
+	}, routing.CheckPrimaryChannels(chains, infos, channels))
+}
+
+func TestCheckPrimaryChannelsMissingData(t *testing.T) {
+	chains := []cns.Chain{
+		{
+			ChainName:      "cosmos-hub",
+			PrimaryChannel: cns.DbStringMap{"osmosis": "channel-141"},
+		},
+		{
+			ChainName: "osmosis",
+		},
+	}
+
+	issues := routing.CheckPrimaryChannels(chains, nil, nil)
+
+	var kinds []routing.IssueKind
+	for _, i := range issues {
+		kinds = append(kinds, i.Kind)
+	}
+
+	require.Equal(t, []routing.IssueKind{routing.IssueChannelNotFound, routing.IssueMissingCounterparty}, kinds)
+}
@@ -0,0 +1,179 @@
+// Package routing builds an IBC routing graph out of CNS chains' primary channels.
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+	"github.com/emerishq/demeris-backend-models/tracelistener"
+)
+
+// TransferPort is the ICS-20 port used by all the primary channels.
+const TransferPort = "transfer"
+
+var (
+	// ErrUnknownChain is returned when a route is requested for a chain not in the graph.
+	ErrUnknownChain = errors.New("unknown chain")
+	// ErrNoRoute is returned when no route exists between two chains.
+	ErrNoRoute = errors.New("no route")
+)
+
+// Hop represents a single IBC transfer from one chain to another.
+type Hop struct {
+	From                string `json:"from"`
+	To                  string `json:"to"`
+	Channel             string `json:"channel"`              // channel on From used to send to To
+	CounterpartyChannel string `json:"counterparty_channel"` // channel on To receiving from From, empty if To has no primary channel for From
+}
+
+// Route is a sequence of hops, each starting from the chain the previous one ended to.
+type Route []Hop
+
+// Chains returns the names of the chains traversed by r, source and destination included.
+func (r Route) Chains() []string {
+	if len(r) == 0 {
+		return nil
+	}
+
+	ret := []string{r[0].From}
+	for _, h := range r {
+		ret = append(ret, h.To)
+	}
+
+	return ret
+}
+
+// DenomTrace returns the denom trace obtained on the destination chain when sending denom along r.
+// denom is the full denom trace on the source chain, e.g. uatom or transfer/channel-0/uosmo.
+// Hops sending a token back through the channel it came from unwind the trace, as defined by ICS-20.
+func (r Route) DenomTrace(denom string) (tracelistener.DenomTrace, error) {
+	dt, err := tracelistener.ParseDenomTrace(denom)
+	if err != nil {
+		return tracelistener.DenomTrace{}, err
+	}
+
+	for _, h := range r {
+		sourcePrefix := TransferPort + "/" + h.Channel
+		if dt.Path == sourcePrefix || strings.HasPrefix(dt.Path, sourcePrefix+"/") {
+			dt.Path = strings.TrimPrefix(strings.TrimPrefix(dt.Path, sourcePrefix), "/")
+			continue
+		}
+
+		if h.CounterpartyChannel == "" {
+			return tracelistener.DenomTrace{}, fmt.Errorf("chain %s has no primary channel for %s", h.To, h.From)
+		}
+
+		destPrefix := TransferPort + "/" + h.CounterpartyChannel
+		if dt.Path == "" {
+			dt.Path = destPrefix
+		} else {
+			dt.Path = destPrefix + "/" + dt.Path
+		}
+	}
+
+	return dt, nil
+}
+
+// Graph is a directed graph of chains, with an edge from chain A to chain B when
+// A's PrimaryChannel has an entry for B.
+// Graph is immutable once built, and safe for concurrent use.
+type Graph struct {
+	edges map[string]map[string]string
+}
+
+// NewGraph builds a Graph out of chains.
+// Primary channels pointing to chains not in chains are ignored.
+func NewGraph(chains []cns.Chain) *Graph {
+	g := &Graph{
+		edges: make(map[string]map[string]string, len(chains)),
+	}
+
+	for _, c := range chains {
+		g.edges[c.ChainName] = map[string]string{}
+	}
+
+	for _, c := range chains {
+		for counterparty, channel := range c.PrimaryChannel {
+			if _, ok := g.edges[counterparty]; !ok || counterparty == c.ChainName {
+				continue
+			}
+
+			g.edges[c.ChainName][counterparty] = channel
+		}
+	}
+
+	return g
+}
+
+// Neighbors returns the chains directly reachable from chain, sorted by name.
+func (g *Graph) Neighbors(chain string) []string {
+	ret := make([]string, 0, len(g.edges[chain]))
+	for n := range g.edges[chain] {
+		ret = append(ret, n)
+	}
+
+	sort.Strings(ret)
+
+	return ret
+}
+
+// Channel returns the primary channel on from used to send tokens to to.
+func (g *Graph) Channel(from, to string) (string, bool) {
+	ch, ok := g.edges[from][to]
+	return ch, ok
+}
+
+// ShortestRoute returns the route with the fewest hops from chain from to chain to.
+// Among routes of equal length, the lexicographically smallest sequence of chain names is returned.
+func (g *Graph) ShortestRoute(from, to string) (Route, error) {
+	for _, c := range []string{from, to} {
+		if _, ok := g.edges[c]; !ok {
+			return nil, fmt.Errorf("%w %s", ErrUnknownChain, c)
+		}
+	}
+
+	if from == to {
+		return Route{}, nil
+	}
+
+	prev := map[string]string{from: ""}
+	queue := []string{from}
+	for len(queue) > 0 && !hasKey(prev, to) {
+		cur := queue[0]
+		queue = queue[1:]
+
+		for _, n := range g.Neighbors(cur) {
+			if hasKey(prev, n) {
+				continue
+			}
+
+			prev[n] = cur
+			queue = append(queue, n)
+		}
+	}
+
+	if !hasKey(prev, to) {
+		return nil, fmt.Errorf("%w from %s to %s", ErrNoRoute, from, to)
+	}
+
+	var route Route
+	for cur := to; cur != from; cur = prev[cur] {
+		p := prev[cur]
+		route = append(Route{{
+			From:                p,
+			To:                  cur,
+			Channel:             g.edges[p][cur],
+			CounterpartyChannel: g.edges[cur][p],
+		}}, route...)
+	}
+
+	return route, nil
+}
+
+func hasKey(m map[string]string, k string) bool {
+	_, ok := m[k]
+	return ok
+}
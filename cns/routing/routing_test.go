@@ -0,0 +1,188 @@
+package routing_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+	"github.com/emerishq/demeris-backend-models/cns/routing"
+	"github.com/emerishq/demeris-backend-models/tracelistener"
+)
+
+// cosmos-hub <-> osmosis <-> akash, crypto-org -> cosmos-hub only, unknown ignored.
+var testChains = []cns.Chain{
+	{
+		ChainName:      "cosmos-hub",
+		PrimaryChannel: cns.DbStringMap{"osmosis": "channel-141", "crypto-org": "channel-187"},
+	},
+	{
+		ChainName:      "osmosis",
+		PrimaryChannel: cns.DbStringMap{"cosmos-hub": "channel-0", "akash": "channel-1", "unknown": "channel-99"},
+	},
+	{
+		ChainName:      "akash",
+		PrimaryChannel: cns.DbStringMap{"osmosis": "channel-9"},
+	},
+	{
+		ChainName:      "crypto-org",
+		PrimaryChannel: cns.DbStringMap{},
+	},
+	{
+		ChainName: "isolated",
+	},
+}
+
+func TestGraphNeighbors(t *testing.T) {
+	g := routing.NewGraph(testChains)
+
+	require.Equal(t, []string{"akash", "cosmos-hub"}, g.Neighbors("osmosis"))
+	require.Equal(t, []string{}, g.Neighbors("isolated"))
+
+	ch, ok := g.Channel("cosmos-hub", "osmosis")
+	require.True(t, ok)
+	require.Equal(t, "channel-141", ch)
+
+	_, ok = g.Channel("osmosis", "unknown")
+	require.False(t, ok)
+}
+
+func TestGraphShortestRoute(t *testing.T) {
+	g := routing.NewGraph(testChains)
+
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		expected routing.Route
+		err      error
+	}{
+		{
+			"direct",
+			"cosmos-hub",
+			"osmosis",
+			routing.Route{{From: "cosmos-hub", To: "osmosis", Channel: "channel-141", CounterpartyChannel: "channel-0"}},
+			nil,
+		},
+		{
+			"multi-hop",
+			"cosmos-hub",
+			"akash",
+			routing.Route{
+				{From: "cosmos-hub", To: "osmosis", Channel: "channel-141", CounterpartyChannel: "channel-0"},
+				{From: "osmosis", To: "akash", Channel: "channel-1", CounterpartyChannel: "channel-9"},
+			},
+			nil,
+		},
+		{
+			"one-way edge",
+			"cosmos-hub",
+			"crypto-org",
+			routing.Route{{From: "cosmos-hub", To: "crypto-org", Channel: "channel-187"}},
+			nil,
+		},
+		{
+			"same chain",
+			"osmosis",
+			"osmosis",
+			routing.Route{},
+			nil,
+		},
+		{
+			"no route",
+			"crypto-org",
+			"cosmos-hub",
+			nil,
+			routing.ErrNoRoute,
+		},
+		{
+			"unknown chain",
+			"cosmos-hub",
+			"unknown",
+			nil,
+			routing.ErrUnknownChain,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := g.ShortestRoute(tt.from, tt.to)
+			require.ErrorIs(t, err, tt.err)
+			require.Equal(t, tt.expected, r)
+		})
+	}
+}
+
+func TestRouteDenomTrace(t *testing.T) {
+	g := routing.NewGraph(testChains)
+
+	tests := []struct {
+		name      string
+		from      string
+		to        string
+		denom     string
+		expected  tracelistener.DenomTrace
+		assertion require.ErrorAssertionFunc
+	}{
+		{
+			"native token, single hop",
+			"cosmos-hub",
+			"osmosis",
+			"uatom",
+			tracelistener.DenomTrace{Path: "transfer/channel-0", BaseDenom: "uatom"},
+			require.NoError,
+		},
+		{
+			"native token, multi-hop",
+			"cosmos-hub",
+			"akash",
+			"uatom",
+			tracelistener.DenomTrace{Path: "transfer/channel-9/transfer/channel-0", BaseDenom: "uatom"},
+			require.NoError,
+		},
+		{
+			"token sent back to its source unwinds",
+			"osmosis",
+			"cosmos-hub",
+			"transfer/channel-0/uatom",
+			tracelistener.DenomTrace{BaseDenom: "uatom"},
+			require.NoError,
+		},
+		{
+			"partial unwind",
+			"akash",
+			"cosmos-hub",
+			"transfer/channel-9/uosmo",
+			tracelistener.DenomTrace{Path: "transfer/channel-141", BaseDenom: "uosmo"},
+			require.NoError,
+		},
+		{
+			"missing counterparty channel",
+			"cosmos-hub",
+			"crypto-org",
+			"uatom",
+			tracelistener.DenomTrace{},
+			require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := g.ShortestRoute(tt.from, tt.to)
+			require.NoError(t, err)
+
+			dt, err := r.DenomTrace(tt.denom)
+			tt.assertion(t, err)
+			require.Equal(t, tt.expected, dt)
+		})
+	}
+}
+
+func TestRouteChains(t *testing.T) {
+	r, err := routing.NewGraph(testChains).ShortestRoute("cosmos-hub", "akash")
+	require.NoError(t, err)
+	require.Equal(t, []string{"cosmos-hub", "osmosis", "akash"}, r.Chains())
+	require.Nil(t, routing.Route{}.Chains())
+}
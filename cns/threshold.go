@@ -0,0 +1,442 @@
+package cns
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ThresholdFormat defines the textual representation of a Threshold.
+type ThresholdFormat int
+
+const (
+	// ThresholdFormatGo formats thresholds as time.Duration strings, e.g. 1m30s.
+	ThresholdFormatGo ThresholdFormat = iota
+	// ThresholdFormatPostgres formats thresholds as Postgres interval output, e.g. 1 day 00:01:30.
+	ThresholdFormatPostgres
+	// ThresholdFormatISO8601 formats thresholds as ISO-8601 durations, e.g. P1DT1M30S.
+	ThresholdFormatISO8601
+)
+
+const day = 24 * time.Hour
+
+var errInvalidThreshold = errors.New("invalid threshold")
+
+// ParseThreshold parses s as a Threshold.
+// The accepted formats are:
+//   - time.Duration strings, e.g. 1m30s;
+//   - Postgres interval output, e.g. 00:01:30 or 1 day 02:00:00;
+//   - ISO-8601 durations, e.g. PT1M30S or P1DT2H;
+//   - integers, interpreted as nanoseconds.
+//
+// Years and months are rejected, since they do not have a fixed duration.
+func ParseThreshold(s string) (Threshold, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("%w: empty string", errInvalidThreshold)
+	}
+
+	if d, err := time.ParseDuration(s); err == nil {
+		return Threshold(d), nil
+	}
+
+	if ns, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return Threshold(ns), nil
+	}
+
+	if strings.HasPrefix(s, "P") || strings.HasPrefix(s, "-P") {
+		d, err := parseISO8601(s)
+		return Threshold(d), err
+	}
+
+	d, err := parsePostgresInterval(s)
+	return Threshold(d), err
+}
+
+// Format returns the textual representation of t in format f.
+func (t Threshold) Format(f ThresholdFormat) string {
+	switch f {
+	case ThresholdFormatPostgres:
+		return formatPostgresInterval(t.Duration())
+	case ThresholdFormatISO8601:
+		return formatISO8601(t.Duration())
+	default:
+		return t.Duration().String()
+	}
+}
+
+// String implements the fmt.Stringer interface, using ThresholdFormatGo.
+func (t Threshold) String() string {
+	return t.Format(ThresholdFormatGo)
+}
+
+// WithFormat returns t as a FormattedThreshold written in format f.
+func (t Threshold) WithFormat(f ThresholdFormat) FormattedThreshold {
+	return FormattedThreshold{Threshold: t, Format: f}
+}
+
+// FormattedThreshold is a Threshold written to JSON and database values in Format, rather than
+// in ThresholdFormatGo. Any format accepted by ParseThreshold is read back.
+type FormattedThreshold struct {
+	Threshold Threshold
+	Format    ThresholdFormat
+}
+
+// String implements the fmt.Stringer interface.
+func (t FormattedThreshold) String() string {
+	return t.Threshold.Format(t.Format)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t FormattedThreshold) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, Format is left untouched.
+func (t *FormattedThreshold) UnmarshalJSON(bytes []byte) error {
+	return t.Threshold.UnmarshalJSON(bytes)
+}
+
+// Scan is the sql.Scanner implementation for FormattedThreshold, Format is left untouched.
+func (t *FormattedThreshold) Scan(value interface{}) error {
+	return t.Threshold.Scan(value)
+}
+
+// Value is the driver.Value implementation for FormattedThreshold.
+func (t FormattedThreshold) Value() (driver.Value, error) {
+	return driver.Value(t.String()), nil
+}
+
+// addDuration returns a+b, or false if the sum overflows.
+func addDuration(a, b time.Duration) (time.Duration, bool) {
+	if b > 0 && a > math.MaxInt64-b || b < 0 && a < math.MinInt64-b {
+		return 0, false
+	}
+
+	return a + b, true
+}
+
+// parseUnits parses s as a signed integer number of unit, e.g. "-1" days.
+// unit must be positive.
+func parseUnits(s string, unit time.Duration) (time.Duration, error) {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid number %q", errInvalidThreshold, s)
+	}
+
+	if n > math.MaxInt64/int64(unit) || n < math.MinInt64/int64(unit) {
+		return 0, fmt.Errorf("%w: %s overflows", errInvalidThreshold, s)
+	}
+
+	return time.Duration(n) * unit, nil
+}
+
+// parsePostgresInterval parses the "postgres" interval output style, e.g. "-1 days +02:00:00".
+func parsePostgresInterval(s string) (time.Duration, error) {
+	fields := strings.Fields(s)
+
+	var (
+		total     time.Duration
+		seenDays  bool
+		seenClock bool
+	)
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+
+		if strings.Contains(f, ":") {
+			if seenClock {
+				return 0, fmt.Errorf("%w: %q has repeated time", errInvalidThreshold, s)
+			}
+
+			seenClock = true
+
+			d, err := parseClock(f)
+			if err != nil {
+				return 0, err
+			}
+
+			var ok bool
+			if total, ok = addDuration(total, d); !ok {
+				return 0, fmt.Errorf("%w: %q overflows", errInvalidThreshold, s)
+			}
+
+			continue
+		}
+
+		if i+1 >= len(fields) {
+			return 0, fmt.Errorf("%w: %q is missing a unit", errInvalidThreshold, s)
+		}
+
+		i++
+		switch fields[i] {
+		case "day", "days":
+			if seenDays {
+				return 0, fmt.Errorf("%w: %q has repeated unit %q", errInvalidThreshold, s, fields[i])
+			}
+
+			seenDays = true
+		default:
+			return 0, fmt.Errorf("%w: unsupported interval unit %q", errInvalidThreshold, fields[i])
+		}
+
+		d, err := parseUnits(f, day)
+		if err != nil {
+			return 0, err
+		}
+
+		var ok bool
+		if total, ok = addDuration(total, d); !ok {
+			return 0, fmt.Errorf("%w: %q overflows", errInvalidThreshold, s)
+		}
+	}
+
+	return total, nil
+}
+
+// parseClock parses a [+-]HH:MM[:SS[.ffffff]] string.
+func parseClock(s string) (time.Duration, error) {
+	sign := time.Duration(1)
+	switch {
+	case strings.HasPrefix(s, "-"):
+		sign = -1
+		s = s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("%w: invalid time %q", errInvalidThreshold, s)
+	}
+
+	h, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || h < 0 || h > math.MaxInt64/int64(time.Hour) {
+		return 0, fmt.Errorf("%w: invalid hours %q", errInvalidThreshold, parts[0])
+	}
+
+	m, err := strconv.ParseUint(parts[1], 10, 8)
+	if err != nil || m > 59 {
+		return 0, fmt.Errorf("%w: invalid minutes %q", errInvalidThreshold, parts[1])
+	}
+
+	d, ok := addDuration(time.Duration(h)*time.Hour, time.Duration(m)*time.Minute)
+	if !ok {
+		return 0, fmt.Errorf("%w: %q overflows", errInvalidThreshold, s)
+	}
+
+	if len(parts) == 3 {
+		sec, err := parseSeconds(parts[2])
+		if err != nil || sec >= time.Minute {
+			return 0, fmt.Errorf("%w: invalid seconds %q", errInvalidThreshold, parts[2])
+		}
+
+		var ok bool
+		if d, ok = addDuration(d, sec); !ok {
+			return 0, fmt.Errorf("%w: %q overflows", errInvalidThreshold, s)
+		}
+	}
+
+	return sign * d, nil
+}
+
+// parseSeconds parses a non-negative decimal number of seconds, e.g. 1.5, with up to nanosecond precision.
+func parseSeconds(s string) (time.Duration, error) {
+	intPart, fracPart := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, fracPart = s[:i], s[i+1:]
+	}
+
+	if intPart == "" || len(fracPart) > 9 || !isDigits(intPart) || !isDigits(fracPart) {
+		return 0, fmt.Errorf("%w: invalid seconds %q", errInvalidThreshold, s)
+	}
+
+	sec, err := strconv.ParseInt(intPart, 10, 64)
+	if err != nil || sec > math.MaxInt64/int64(time.Second) {
+		return 0, fmt.Errorf("%w: invalid seconds %q", errInvalidThreshold, s)
+	}
+
+	var ns int64
+	if fracPart != "" {
+		ns, _ = strconv.ParseInt(fracPart+strings.Repeat("0", 9-len(fracPart)), 10, 64)
+	}
+
+	return time.Duration(sec)*time.Second + time.Duration(ns), nil
+}
+
+// parseISO8601 parses an ISO-8601 duration such as P1DT2H30M or PT0.5S.
+func parseISO8601(s string) (time.Duration, error) {
+	sign := time.Duration(1)
+	str := s
+	if strings.HasPrefix(str, "-") {
+		sign = -1
+		str = str[1:]
+	}
+
+	str = strings.TrimPrefix(str, "P")
+	if str == "" || str == "T" {
+		return 0, fmt.Errorf("%w: empty ISO-8601 duration %q", errInvalidThreshold, s)
+	}
+
+	var total time.Duration
+	seen := map[time.Duration]bool{}
+	inTime := false
+	num := ""
+	for _, r := range str {
+		switch {
+		case r == 'T':
+			if inTime || num != "" {
+				return 0, fmt.Errorf("%w: %q", errInvalidThreshold, s)
+			}
+
+			inTime = true
+		case r >= '0' && r <= '9', r == '.', r == ',':
+			num += string(r)
+		default:
+			if num == "" {
+				return 0, fmt.Errorf("%w: %q", errInvalidThreshold, s)
+			}
+
+			unit, err := iso8601Unit(r, inTime)
+			if err != nil {
+				return 0, fmt.Errorf("%w: %q, %s", errInvalidThreshold, s, err.Error())
+			}
+
+			if seen[unit] {
+				return 0, fmt.Errorf("%w: %q has repeated unit %q", errInvalidThreshold, s, r)
+			}
+
+			seen[unit] = true
+
+			var d time.Duration
+			if unit == time.Second {
+				d, err = parseSeconds(strings.Replace(num, ",", ".", 1))
+			} else {
+				d, err = parseUnits(num, unit)
+			}
+
+			if err != nil {
+				return 0, fmt.Errorf("%w: %q", errInvalidThreshold, s)
+			}
+
+			var ok bool
+			if total, ok = addDuration(total, d); !ok {
+				return 0, fmt.Errorf("%w: %q overflows", errInvalidThreshold, s)
+			}
+
+			num = ""
+		}
+	}
+
+	if num != "" {
+		return 0, fmt.Errorf("%w: %q is missing a unit", errInvalidThreshold, s)
+	}
+
+	return sign * total, nil
+}
+
+func iso8601Unit(r rune, inTime bool) (time.Duration, error) {
+	switch {
+	case !inTime && r == 'W':
+		return 7 * day, nil
+	case !inTime && r == 'D':
+		return day, nil
+	case inTime && r == 'H':
+		return time.Hour, nil
+	case inTime && r == 'M':
+		return time.Minute, nil
+	case inTime && r == 'S':
+		return time.Second, nil
+	case !inTime && (r == 'Y' || r == 'M'):
+		return 0, errors.New("years and months are not supported")
+	default:
+		return 0, fmt.Errorf("unknown unit %q", r)
+	}
+}
+
+func formatPostgresInterval(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+
+	var sb strings.Builder
+	if days := d / day; days > 0 {
+		// as Postgres, only a positive single day is singular, e.g. "1 day" and "-1 days".
+		unit := "days"
+		if days == 1 && sign == "" {
+			unit = "day"
+		}
+
+		fmt.Fprintf(&sb, "%s%d %s", sign, days, unit)
+		d -= days * day
+
+		// the time is omitted when zero, e.g. "2 days".
+		if d == 0 {
+			return sb.String()
+		}
+
+		sb.WriteByte(' ')
+	}
+
+	h := d / time.Hour
+	m := (d % time.Hour) / time.Minute
+	s := (d % time.Minute) / time.Second
+	ns := d % time.Second
+
+	fmt.Fprintf(&sb, "%s%02d:%02d:%02d", sign, h, m, s)
+	if ns != 0 {
+		sb.WriteString(strings.TrimRight(fmt.Sprintf(".%09d", ns), "0"))
+	}
+
+	return sb.String()
+}
+
+func formatISO8601(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+
+	var sb strings.Builder
+	if d < 0 {
+		sb.WriteByte('-')
+		d = -d
+	}
+
+	sb.WriteByte('P')
+	if days := d / day; days > 0 {
+		fmt.Fprintf(&sb, "%dD", days)
+		d -= days * day
+	}
+
+	if d == 0 {
+		return sb.String()
+	}
+
+	sb.WriteByte('T')
+	if h := d / time.Hour; h > 0 {
+		fmt.Fprintf(&sb, "%dH", h)
+	}
+
+	if m := (d % time.Hour) / time.Minute; m > 0 {
+		fmt.Fprintf(&sb, "%dM", m)
+	}
+
+	if rem := d % time.Minute; rem > 0 {
+		s := rem / time.Second
+		ns := rem % time.Second
+		fmt.Fprintf(&sb, "%d", s)
+		if ns != 0 {
+			sb.WriteString(strings.TrimRight(fmt.Sprintf(".%09d", ns), "0"))
+		}
+
+		sb.WriteByte('S')
+	}
+
+	return sb.String()
+}
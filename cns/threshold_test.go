@@ -0,0 +1,161 @@
+package cns_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+)
+
+func TestParseThreshold(t *testing.T) {
+	tests := []struct {
+		value     string
+		expected  time.Duration
+		assertion require.ErrorAssertionFunc
+	}{
+		{"1m30s", 90 * time.Second, require.NoError},
+		{"90000000000", 90 * time.Second, require.NoError},
+		{"00:01:30", 90 * time.Second, require.NoError},
+		{"00:00:00.5", 500 * time.Millisecond, require.NoError},
+		{"1 day 02:00:00", 26 * time.Hour, require.NoError},
+		{"3 days", 72 * time.Hour, require.NoError},
+		{"-00:00:05", -5 * time.Second, require.NoError},
+		{"-1 days +02:00:00", -22 * time.Hour, require.NoError},
+		{"PT1M30S", 90 * time.Second, require.NoError},
+		{"P1DT2H", 26 * time.Hour, require.NoError},
+		{"P1W", 7 * 24 * time.Hour, require.NoError},
+		{"PT0.25S", 250 * time.Millisecond, require.NoError},
+		{"-PT5S", -5 * time.Second, require.NoError},
+		{"", 0, require.Error},
+		{"foo", 0, require.Error},
+		{"1 mon", 0, require.Error},
+		{"P1M", 0, require.Error},
+		{"P1Y", 0, require.Error},
+		{"PT", 0, require.Error},
+		{"PT5", 0, require.Error},
+		{"00:61:00", 0, require.Error},
+		{"1 day", 24 * time.Hour, require.NoError},
+		{"1", 1, require.NoError},
+		{"106751 days", 106751 * 24 * time.Hour, require.NoError},
+		{"106752 days", 0, require.Error},
+		{"106751 days 23:59:59", 0, require.Error},
+		{"2562048:00:00", 0, require.Error},
+		{"P999999999999D", 0, require.Error},
+		{"P15251W", 0, require.Error},
+		{"1 day 1 day", 0, require.Error},
+		{"00:01:00 00:01:00", 0, require.Error},
+		{"P1DT1H1H", 0, require.Error},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.value, func(t *testing.T) {
+			res, err := cns.ParseThreshold(tt.value)
+			tt.assertion(t, err)
+			require.Equal(t, tt.expected, res.Duration())
+		})
+	}
+}
+
+func TestThresholdFormat(t *testing.T) {
+	tests := []struct {
+		d        time.Duration
+		format   cns.ThresholdFormat
+		expected string
+	}{
+		{90 * time.Second, cns.ThresholdFormatGo, "1m30s"},
+		{90 * time.Second, cns.ThresholdFormatPostgres, "00:01:30"},
+		{26*time.Hour + 1500*time.Millisecond, cns.ThresholdFormatPostgres, "1 day 02:00:01.5"},
+		{50 * time.Hour, cns.ThresholdFormatPostgres, "2 days 02:00:00"},
+		{-5 * time.Second, cns.ThresholdFormatPostgres, "-00:00:05"},
+		{24 * time.Hour, cns.ThresholdFormatPostgres, "1 day"},
+		{-24 * time.Hour, cns.ThresholdFormatPostgres, "-1 days"},
+		{-26 * time.Hour, cns.ThresholdFormatPostgres, "-1 days -02:00:00"},
+		{0, cns.ThresholdFormatPostgres, "00:00:00"},
+		{90 * time.Second, cns.ThresholdFormatISO8601, "PT1M30S"},
+		{26 * time.Hour, cns.ThresholdFormatISO8601, "P1DT2H"},
+		{24 * time.Hour, cns.ThresholdFormatISO8601, "P1D"},
+		{250 * time.Millisecond, cns.ThresholdFormatISO8601, "PT0.25S"},
+		{0, cns.ThresholdFormatISO8601, "PT0S"},
+		{-5 * time.Second, cns.ThresholdFormatISO8601, "-PT5S"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.expected, func(t *testing.T) {
+			s := cns.Threshold(tt.d).Format(tt.format)
+			require.Equal(t, tt.expected, s)
+
+			// every format must be parsed back to the same value
+			parsed, err := cns.ParseThreshold(s)
+			require.NoError(t, err)
+			require.Equal(t, tt.d, parsed.Duration())
+		})
+	}
+}
+
+func TestThresholdScan(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     interface{}
+		expected  time.Duration
+		assertion require.ErrorAssertionFunc
+	}{
+		{"go duration string", "10s", 10 * time.Second, require.NoError},
+		{"postgres interval bytes", []byte("00:00:10"), 10 * time.Second, require.NoError},
+		{"integer nanoseconds", int64(10 * time.Second), 10 * time.Second, require.NoError},
+		{"unsupported type", 10.5, 0, require.Error},
+		{"invalid string", "foo", 0, require.Error},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var th cns.Threshold
+			tt.assertion(t, th.Scan(tt.value))
+			require.Equal(t, tt.expected, th.Duration())
+		})
+	}
+}
+
+func TestThresholdJSON(t *testing.T) {
+	var th cns.Threshold
+	require.NoError(t, json.Unmarshal([]byte(`"1 day 00:00:10"`), &th))
+	require.Equal(t, 24*time.Hour+10*time.Second, th.Duration())
+
+	require.NoError(t, json.Unmarshal([]byte(`10000000000`), &th))
+	require.Equal(t, 10*time.Second, th.Duration())
+
+	require.Error(t, json.Unmarshal([]byte(`true`), &th))
+
+	b, err := json.Marshal(th)
+	require.NoError(t, err)
+	require.Equal(t, `"10s"`, string(b))
+}
+
+func TestFormattedThreshold(t *testing.T) {
+	th := cns.Threshold(90 * time.Second).WithFormat(cns.ThresholdFormatPostgres)
+
+	v, err := th.Value()
+	require.NoError(t, err)
+	require.Equal(t, "00:01:30", v)
+
+	b, err := json.Marshal(th)
+	require.NoError(t, err)
+	require.Equal(t, `"00:01:30"`, string(b))
+
+	// plain thresholds are not affected
+	b, err = json.Marshal(cns.Threshold(90 * time.Second))
+	require.NoError(t, err)
+	require.Equal(t, `"1m30s"`, string(b))
+
+	iso := cns.Threshold(0).WithFormat(cns.ThresholdFormatISO8601)
+	require.NoError(t, json.Unmarshal([]byte(`"00:00:10"`), &iso))
+	require.Equal(t, "PT10S", iso.String())
+
+	require.NoError(t, iso.Scan("1m"))
+	require.Equal(t, "PT1M", iso.String())
+}
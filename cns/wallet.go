@@ -0,0 +1,177 @@
+package cns
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ErrUnknownWallet is returned when a wallet identifier is not part of the wallet registry.
+var ErrUnknownWallet = errors.New("unknown wallet")
+
+// ethCoinType is the SLIP-44 coin type of chains using eth_secp256k1 keys, e.g. Evmos.
+const ethCoinType = 60
+
+// Wallet identifies a wallet the frontend can connect to, as stored in Chain.SupportedWallets.
+type Wallet string
+
+const (
+	// WalletKeplr is the Keplr browser extension and mobile wallet.
+	WalletKeplr Wallet = "keplr"
+	// WalletLedger is the Ledger hardware wallet, with the Cosmos app.
+	WalletLedger Wallet = "ledger"
+	// WalletCosmostation is the Cosmostation browser extension and mobile wallet.
+	WalletCosmostation Wallet = "cosmostation"
+	// WalletLeap is the Leap browser extension wallet.
+	WalletLeap Wallet = "leap"
+	// WalletMetaMaskSnap is MetaMask with the Cosmos snap installed.
+	WalletMetaMaskSnap Wallet = "metamask-snap"
+)
+
+// WalletInfo holds a wallet capabilities.
+type WalletInfo struct {
+	Wallet       Wallet   `json:"wallet"`
+	Name         string   `json:"name"`
+	SuggestChain bool     `json:"suggest_chain"`        // chains not natively supported can be added to the wallet
+	EthSecp256k1 bool     `json:"eth_secp256k1"`        // eth_secp256k1 keys, i.e. coin type 60, are supported
+	AminoOnly    bool     `json:"amino_only"`           // chains only accepting amino JSON signatures are supported
+	CoinTypes    []uint32 `json:"coin_types,omitempty"` // the only coin types the wallet derives keys for, any if empty
+}
+
+// wallets is the wallet registry.
+var wallets = map[Wallet]WalletInfo{
+	WalletKeplr: {
+		Wallet:       WalletKeplr,
+		Name:         "Keplr",
+		SuggestChain: true,
+		EthSecp256k1: true,
+		AminoOnly:    true,
+	},
+	WalletLedger: {
+		Wallet:    WalletLedger,
+		Name:      "Ledger",
+		AminoOnly: true,
+		CoinTypes: []uint32{118},
+	},
+	WalletCosmostation: {
+		Wallet:       WalletCosmostation,
+		Name:         "Cosmostation",
+		SuggestChain: true,
+		EthSecp256k1: true,
+		AminoOnly:    true,
+	},
+	WalletLeap: {
+		Wallet:       WalletLeap,
+		Name:         "Leap",
+		SuggestChain: true,
+		EthSecp256k1: true,
+	},
+	WalletMetaMaskSnap: {
+		Wallet:       WalletMetaMaskSnap,
+		Name:         "MetaMask Snap",
+		SuggestChain: true,
+	},
+}
+
+// AllWallets returns the registry of known wallets, sorted by identifier.
+func AllWallets() []WalletInfo {
+	ret := make([]WalletInfo, 0, len(wallets))
+	for _, w := range wallets {
+		ret = append(ret, w)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Wallet < ret[j].Wallet
+	})
+
+	return ret
+}
+
+// ParseWallet returns the Wallet identified by s, or ErrUnknownWallet.
+// Identifiers are case-insensitive, and spaces or underscores stand for hyphens: "Keplr" is
+// WalletKeplr and "MetaMask Snap" is WalletMetaMaskSnap, while "kepler" is unknown.
+func ParseWallet(s string) (Wallet, error) {
+	w := normalizeWallet(s)
+	if _, ok := wallets[w]; !ok {
+		return "", fmt.Errorf("%w %q", ErrUnknownWallet, s)
+	}
+
+	return w, nil
+}
+
+func normalizeWallet(s string) Wallet {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '_' || r == '-'
+	})
+
+	return Wallet(strings.ToLower(strings.Join(fields, "-")))
+}
+
+// Info returns w's capabilities.
+func (w Wallet) Info() (WalletInfo, bool) {
+	info, ok := wallets[w]
+	return info, ok
+}
+
+// Wallets returns c's SupportedWallets, or an error if any of them is unknown.
+func (c Chain) Wallets() ([]Wallet, error) {
+	ret := make([]Wallet, 0, len(c.SupportedWallets))
+	for _, s := range c.SupportedWallets {
+		w, err := ParseWallet(s)
+		if err != nil {
+			return nil, fmt.Errorf("chain %s: %w", c.ChainName, err)
+		}
+
+		ret = append(ret, w)
+	}
+
+	return ret, nil
+}
+
+// CompatibleWallets returns the wallets able to operate on c, sorted by identifier:
+//   - c's coin type must be derived by the wallet, and coin type 60 requires eth_secp256k1 support
+//   - chains before Cosmos SDK v0.40 require amino-only support
+//   - chains with PublicNodeEndpoints, i.e. not natively supported, require suggest chain support
+func (c Chain) CompatibleWallets() ([]Wallet, error) {
+	coinType, err := c.CoinType()
+	if err != nil {
+		return nil, err
+	}
+
+	aminoOnly, err := c.Supports(CapabilityLegacyAminoSigning)
+	if err != nil {
+		return nil, err
+	}
+
+	suggest := len(c.PublicNodeEndpoints.TendermintRPC) > 0 || len(c.PublicNodeEndpoints.CosmosAPI) > 0
+
+	var ret []Wallet
+	for _, w := range AllWallets() {
+		if !w.derives(coinType) ||
+			coinType == ethCoinType && !w.EthSecp256k1 ||
+			aminoOnly && !w.AminoOnly ||
+			suggest && !w.SuggestChain {
+			continue
+		}
+
+		ret = append(ret, w.Wallet)
+	}
+
+	return ret, nil
+}
+
+func (w WalletInfo) derives(coinType uint32) bool {
+	if len(w.CoinTypes) == 0 {
+		return true
+	}
+
+	for _, ct := range w.CoinTypes {
+		if ct == coinType {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,108 @@
+package cns_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+)
+
+func TestParseWallet(t *testing.T) {
+	w, err := cns.ParseWallet("keplr")
+	require.NoError(t, err)
+	require.Equal(t, cns.WalletKeplr, w)
+
+	w, err = cns.ParseWallet(" Keplr")
+	require.NoError(t, err)
+	require.Equal(t, cns.WalletKeplr, w)
+
+	w, err = cns.ParseWallet("MetaMask Snap")
+	require.NoError(t, err)
+	require.Equal(t, cns.WalletMetaMaskSnap, w)
+
+	for _, s := range []string{"kepler", "Some other", ""} {
+		_, err := cns.ParseWallet(s)
+		require.ErrorIs(t, err, cns.ErrUnknownWallet, s)
+	}
+
+	for _, info := range cns.AllWallets() {
+		got, ok := info.Wallet.Info()
+		require.True(t, ok)
+		require.Equal(t, info, got)
+	}
+}
+
+func TestChainWallets(t *testing.T) {
+	w, err := cns.Chain{SupportedWallets: []string{"keplr", "ledger"}}.Wallets()
+	require.NoError(t, err)
+	require.Equal(t, []cns.Wallet{cns.WalletKeplr, cns.WalletLedger}, w)
+
+	_, err = cns.Chain{SupportedWallets: []string{"keplr", "kepler"}}.Wallets()
+	require.ErrorIs(t, err, cns.ErrUnknownWallet)
+}
+
+func TestChainCompatibleWallets(t *testing.T) {
+	tests := []struct {
+		name      string
+		chain     cns.Chain
+		expected  []cns.Wallet
+		assertion require.ErrorAssertionFunc
+	}{
+		{
+			"stargate chain with coin type 118",
+			cns.Chain{DerivationPath: "m/44'/118'/0'/0/0", CosmosSDKVersion: "v0.45.4"},
+			[]cns.Wallet{cns.WalletCosmostation, cns.WalletKeplr, cns.WalletLeap, cns.WalletLedger, cns.WalletMetaMaskSnap},
+			require.NoError,
+		},
+		{
+			"eth_secp256k1 chain",
+			cns.Chain{DerivationPath: "m/44'/60'/0'/0/0", CosmosSDKVersion: "v0.45.4"},
+			[]cns.Wallet{cns.WalletCosmostation, cns.WalletKeplr, cns.WalletLeap},
+			require.NoError,
+		},
+		{
+			"other coin type",
+			cns.Chain{DerivationPath: "m/44'/459'/0'/0/0", CosmosSDKVersion: "v0.45.4"},
+			[]cns.Wallet{cns.WalletCosmostation, cns.WalletKeplr, cns.WalletLeap, cns.WalletMetaMaskSnap},
+			require.NoError,
+		},
+		{
+			"amino-only chain",
+			cns.Chain{DerivationPath: "m/44'/118'/0'/0/0", CosmosSDKVersion: "v0.39.2"},
+			[]cns.Wallet{cns.WalletCosmostation, cns.WalletKeplr, cns.WalletLedger},
+			require.NoError,
+		},
+		{
+			"chain not natively supported",
+			cns.Chain{
+				DerivationPath:      "m/44'/118'/0'/0/0",
+				CosmosSDKVersion:    "v0.45.4",
+				PublicNodeEndpoints: cns.PublicNodeEndpoints{TendermintRPC: []string{"https://rpc.example.com:443"}},
+			},
+			[]cns.Wallet{cns.WalletCosmostation, cns.WalletKeplr, cns.WalletLeap, cns.WalletMetaMaskSnap},
+			require.NoError,
+		},
+		{
+			"invalid derivation path",
+			cns.Chain{DerivationPath: "44'/118'", CosmosSDKVersion: "v0.45.4"},
+			nil,
+			require.Error,
+		},
+		{
+			"invalid sdk version",
+			cns.Chain{DerivationPath: "m/44'/118'/0'/0/0", CosmosSDKVersion: "0.45.4"},
+			nil,
+			require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := tt.chain.CompatibleWallets()
+			tt.assertion(t, err)
+			require.Equal(t, tt.expected, res)
+		})
+	}
+}
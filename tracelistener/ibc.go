@@ -0,0 +1,152 @@
+package tracelistener
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	ibcDenomPrefix  = "ibc/"
+	channelIDPrefix = "channel-"
+)
+
+// IBC channel states, as stored in IBCChannelRow.State.
+const (
+	ChannelStateUninitialized int32 = iota
+	ChannelStateInit
+	ChannelStateTryOpen
+	ChannelStateOpen
+	ChannelStateClosed
+)
+
+// ErrHashMismatch is returned when an IBCDenomTraceRow Hash doesn't match its Path and BaseDenom.
+var ErrHashMismatch = errors.New("denom trace hash mismatch")
+
+// DenomTraceHop represents a single port/channel pair a token travelled through.
+type DenomTraceHop struct {
+	Port    string `json:"port"`
+	Channel string `json:"channel"`
+}
+
+// String implements the fmt.Stringer interface.
+func (h DenomTraceHop) String() string {
+	return h.Port + "/" + h.Channel
+}
+
+// DenomTrace represents an IBC fungible token denomination trace, as defined by ICS-20.
+type DenomTrace struct {
+	Path      string `json:"path"`
+	BaseDenom string `json:"base_denom"`
+}
+
+// ParseDenomTrace parses a full denom trace such as transfer/channel-0/transfer/channel-5/uatom.
+// The base denom can contain slashes, e.g. transfer/channel-0/gamm/pool/1.
+func ParseDenomTrace(fullPath string) (DenomTrace, error) {
+	parts := strings.Split(fullPath, "/")
+
+	var hops []string
+	i := 0
+	for ; i+1 < len(parts)-1; i += 2 {
+		if !strings.HasPrefix(parts[i+1], channelIDPrefix) || parts[i] == "" {
+			break
+		}
+
+		hops = append(hops, parts[i], parts[i+1])
+	}
+
+	dt := DenomTrace{
+		Path:      strings.Join(hops, "/"),
+		BaseDenom: strings.Join(parts[i:], "/"),
+	}
+
+	if err := dt.Validate(); err != nil {
+		return DenomTrace{}, err
+	}
+
+	return dt, nil
+}
+
+// Hops returns the port/channel pairs contained in dt's Path, in order.
+func (dt DenomTrace) Hops() ([]DenomTraceHop, error) {
+	if dt.Path == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(dt.Path, "/")
+	if len(parts)%2 != 0 {
+		return nil, fmt.Errorf("denom trace path %s is not made of port/channel pairs", dt.Path)
+	}
+
+	hops := make([]DenomTraceHop, 0, len(parts)/2)
+	for i := 0; i < len(parts); i += 2 {
+		if parts[i] == "" || !strings.HasPrefix(parts[i+1], channelIDPrefix) {
+			return nil, fmt.Errorf("denom trace path %s contains invalid hop %s/%s", dt.Path, parts[i], parts[i+1])
+		}
+
+		hops = append(hops, DenomTraceHop{Port: parts[i], Channel: parts[i+1]})
+	}
+
+	return hops, nil
+}
+
+// Validate returns an error if dt is not a well-formed denom trace.
+func (dt DenomTrace) Validate() error {
+	if strings.TrimSpace(dt.BaseDenom) == "" {
+		return errors.New("denom trace base denom cannot be empty")
+	}
+
+	_, err := dt.Hops()
+	return err
+}
+
+// FullPath returns the full denom trace, e.g. transfer/channel-0/uatom.
+func (dt DenomTrace) FullPath() string {
+	if dt.Path == "" {
+		return dt.BaseDenom
+	}
+
+	return dt.Path + "/" + dt.BaseDenom
+}
+
+// Hash returns the upper-case hex encoded SHA256 hash of dt's full path.
+func (dt DenomTrace) Hash() string {
+	h := sha256.Sum256([]byte(dt.FullPath()))
+	return strings.ToUpper(hex.EncodeToString(h[:]))
+}
+
+// IBCDenom returns the denom used on chain to represent dt, i.e. ibc/<hash>.
+// Native tokens, which have an empty Path, are returned as their base denom.
+func (dt DenomTrace) IBCDenom() string {
+	if dt.Path == "" {
+		return dt.BaseDenom
+	}
+
+	return ibcDenomPrefix + dt.Hash()
+}
+
+// DenomTrace returns the DenomTrace represented by c.
+func (c IBCDenomTraceRow) DenomTrace() DenomTrace {
+	return DenomTrace{
+		Path:      c.Path,
+		BaseDenom: c.BaseDenom,
+	}
+}
+
+// ValidateHash returns an error if c's Hash doesn't match the hash of its Path and BaseDenom.
+// Hash is compared case-insensitively, with or without the ibc/ prefix.
+func (c IBCDenomTraceRow) ValidateHash() error {
+	dt := c.DenomTrace()
+	if err := dt.Validate(); err != nil {
+		return err
+	}
+
+	hash := strings.TrimPrefix(c.Hash, ibcDenomPrefix)
+	if !strings.EqualFold(hash, dt.Hash()) {
+		return fmt.Errorf("%w: %s has hash %s, stored hash is %s", ErrHashMismatch, dt.FullPath(), dt.Hash(), c.Hash)
+	}
+
+	return nil
+}
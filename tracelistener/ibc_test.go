@@ -0,0 +1,133 @@
+package tracelistener_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/emerishq/demeris-backend-models/tracelistener"
+)
+
+const atomOnOsmosisHash = "27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2"
+
+func TestParseDenomTrace(t *testing.T) {
+	tests := []struct {
+		name      string
+		fullPath  string
+		expected  tracelistener.DenomTrace
+		hops      []tracelistener.DenomTraceHop
+		assertion require.ErrorAssertionFunc
+	}{
+		{
+			"native denom",
+			"uatom",
+			tracelistener.DenomTrace{BaseDenom: "uatom"},
+			nil,
+			require.NoError,
+		},
+		{
+			"single hop",
+			"transfer/channel-0/uatom",
+			tracelistener.DenomTrace{Path: "transfer/channel-0", BaseDenom: "uatom"},
+			[]tracelistener.DenomTraceHop{{Port: "transfer", Channel: "channel-0"}},
+			require.NoError,
+		},
+		{
+			"multiple hops",
+			"transfer/channel-0/transfer/channel-5/uatom",
+			tracelistener.DenomTrace{Path: "transfer/channel-0/transfer/channel-5", BaseDenom: "uatom"},
+			[]tracelistener.DenomTraceHop{
+				{Port: "transfer", Channel: "channel-0"},
+				{Port: "transfer", Channel: "channel-5"},
+			},
+			require.NoError,
+		},
+		{
+			"base denom with slashes",
+			"transfer/channel-0/gamm/pool/1",
+			tracelistener.DenomTrace{Path: "transfer/channel-0", BaseDenom: "gamm/pool/1"},
+			[]tracelistener.DenomTraceHop{{Port: "transfer", Channel: "channel-0"}},
+			require.NoError,
+		},
+		{
+			"only a hop",
+			"transfer/channel-0",
+			tracelistener.DenomTrace{BaseDenom: "transfer/channel-0"},
+			nil,
+			require.NoError,
+		},
+		{
+			"empty",
+			"",
+			tracelistener.DenomTrace{},
+			nil,
+			require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			dt, err := tracelistener.ParseDenomTrace(tt.fullPath)
+			tt.assertion(t, err)
+			require.Equal(t, tt.expected, dt)
+
+			if err != nil {
+				return
+			}
+
+			require.Equal(t, tt.fullPath, dt.FullPath())
+
+			hops, err := dt.Hops()
+			require.NoError(t, err)
+			require.Equal(t, tt.hops, hops)
+		})
+	}
+}
+
+func TestDenomTraceIBCDenom(t *testing.T) {
+	dt := tracelistener.DenomTrace{Path: "transfer/channel-0", BaseDenom: "uatom"}
+	require.Equal(t, atomOnOsmosisHash, dt.Hash())
+	require.Equal(t, "ibc/"+atomOnOsmosisHash, dt.IBCDenom())
+
+	require.Equal(t, "uatom", tracelistener.DenomTrace{BaseDenom: "uatom"}.IBCDenom())
+}
+
+func TestIBCDenomTraceRowValidateHash(t *testing.T) {
+	tests := []struct {
+		name      string
+		row       tracelistener.IBCDenomTraceRow
+		assertion require.ErrorAssertionFunc
+	}{
+		{
+			"upper-case hash",
+			tracelistener.IBCDenomTraceRow{Path: "transfer/channel-0", BaseDenom: "uatom", Hash: atomOnOsmosisHash},
+			require.NoError,
+		},
+		{
+			"lower-case hash with prefix",
+			tracelistener.IBCDenomTraceRow{Path: "transfer/channel-0", BaseDenom: "uatom", Hash: "ibc/27394fb092d2eccd56123c74f36e4c1f926001ceada9ca97ea622b25f41e5eb2"},
+			require.NoError,
+		},
+		{
+			"hash of another path",
+			tracelistener.IBCDenomTraceRow{Path: "transfer/channel-1", BaseDenom: "uatom", Hash: atomOnOsmosisHash},
+			require.Error,
+		},
+		{
+			"invalid path",
+			tracelistener.IBCDenomTraceRow{Path: "transfer", BaseDenom: "uatom", Hash: atomOnOsmosisHash},
+			require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tt.assertion(t, tt.row.ValidateHash())
+		})
+	}
+
+	err := tracelistener.IBCDenomTraceRow{Path: "transfer/channel-1", BaseDenom: "uatom", Hash: atomOnOsmosisHash}.ValidateHash()
+	require.ErrorIs(t, err, tracelistener.ErrHashMismatch)
+}
@@ -0,0 +1,32 @@
+package validation
+
+import (
+	"reflect"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/emerishq/demeris-backend-models/cns"
+)
+
+const walletStr = "wallet"
+
+// Wallet registers the "wallet" tag, validating that a field is a known cns.Wallet identifier,
+// as parsed by cns.ParseWallet.
+func Wallet(structValidator binding.StructValidator) {
+	if v, ok := structValidator.Engine().(*validator.Validate); ok {
+		if err := v.RegisterValidation(walletStr, registerWalletValidation); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func registerWalletValidation(fl validator.FieldLevel) bool {
+	if fl.Field().Kind() != reflect.String {
+		return false
+	}
+
+	_, err := cns.ParseWallet(fl.Field().String())
+
+	return err == nil
+}
@@ -0,0 +1,57 @@
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/emerishq/demeris-backend-models/validation"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalletValidation(t *testing.T) {
+	type testStruct struct {
+		Wallets []string `binding:"dive,wallet"` //nolint: govet
+	}
+
+	tests := []struct {
+		name      string
+		wallets   []string
+		assertion require.ErrorAssertionFunc
+	}{
+		{
+			"known wallets pass validation",
+			[]string{"keplr", "ledger", "metamask-snap"},
+			require.NoError,
+		},
+		{
+			"typo makes validation fail",
+			[]string{"keplr", "kepler"},
+			require.Error,
+		},
+		{
+			"wallet identifiers are case-insensitive",
+			[]string{"Keplr", "MetaMask Snap"},
+			require.NoError,
+		},
+		{
+			"unknown wallets fail validation",
+			[]string{"Some other"},
+			require.Error,
+		},
+		{
+			"names close to a known wallet fail validation",
+			[]string{"leaf"},
+			require.Error,
+		},
+	}
+	validation.Wallet(binding.Validator)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := binding.Validator.ValidateStruct(testStruct{
+				Wallets: tt.wallets,
+			})
+
+			tt.assertion(t, e)
+		})
+	}
+}